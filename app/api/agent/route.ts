@@ -3,8 +3,9 @@
 
 import { NextResponse } from "next/server";
 import { ConvexHttpClient } from "convex/browser";
-import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../convex/_generated/api";
+import { getCallerClerkId, unauthorizedResponse } from "../../../lib/apiAuth";
+import { errorResponse, caughtErrorResponse } from "../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -30,10 +31,7 @@ export async function GET(request: Request) {
   const wallet = url.searchParams.get("wallet");
 
   if (!wallet) {
-    return NextResponse.json(
-      { error: "wallet parameter required" },
-      { status: 400 },
-    );
+    return errorResponse(400, "wallet parameter required");
   }
 
   try {
@@ -42,22 +40,19 @@ export async function GET(request: Request) {
       walletAddress: wallet,
     });
     if (!agent) {
-      return NextResponse.json({ error: "Agent not found" }, { status: 404 });
+      return errorResponse(404, "Agent not found");
     }
     return NextResponse.json({ agent });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Lookup failed" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Lookup failed");
   }
 }
 
 export async function POST(request: Request) {
   try {
-    const { userId: clerkUserId } = await auth();
+    const clerkUserId = await getCallerClerkId();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return unauthorizedResponse();
     }
 
     const convex = getConvexClient();
@@ -67,7 +62,7 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!user) {
-      return NextResponse.json({ error: "User profile not found" }, { status: 404 });
+      return errorResponse(404, "User profile not found");
     }
 
     const body = await request.json();
@@ -75,7 +70,7 @@ export async function POST(request: Request) {
       user.role !== "admin" &&
       !isSameAddress(user.walletAddress, body.ownerAddress)
     ) {
-      return NextResponse.json({ error: "Forbidden" }, { status: 403 });
+      return errorResponse(403, "Forbidden");
     }
 
     const agentId = await convex.mutation(api.agents.register, {
@@ -87,9 +82,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json({ agentId }, { status: 201 });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Registration failed" },
-      { status: 400 },
-    );
+    return caughtErrorResponse(error, "Registration failed", 400);
   }
 }
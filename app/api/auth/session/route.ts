@@ -0,0 +1,66 @@
+/// app/api/auth/session/route.ts — Bearer token introspection and refresh for API clients
+/// GET: who is calling, POST: mint a fresh Clerk session token before the current one expires
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import { auth, clerkClient } from "@clerk/nextjs/server";
+import { api } from "../../../../convex/_generated/api";
+import { unauthorizedResponse } from "../../../../lib/apiAuth";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+  return new ConvexHttpClient(convexUrl);
+}
+
+function getConvexServiceToken() {
+  const token = process.env.CONVEX_SERVICE_TOKEN;
+  if (!token) throw new Error("CONVEX_SERVICE_TOKEN is not set");
+  return token;
+}
+
+export async function GET() {
+  try {
+    const session = await auth({ acceptsToken: ["session_token", "api_key"] });
+    if (!session.userId) {
+      return unauthorizedResponse();
+    }
+
+    const convex = getConvexClient();
+    const user = await convex.query(api.users.getByClerkId, {
+      clerkId: session.userId,
+      serviceToken: getConvexServiceToken(),
+    });
+
+    return NextResponse.json({
+      clerkId: session.userId,
+      tokenType: session.tokenType,
+      userId: user?._id ?? null,
+      role: user?.role ?? null,
+      walletAddress: user?.walletAddress ?? null,
+    });
+  } catch (error) {
+    return caughtErrorResponse(error, "Session lookup failed");
+  }
+}
+
+export async function POST() {
+  try {
+    const session = await auth({ acceptsToken: ["session_token", "api_key"] });
+    if (!session.userId) {
+      return unauthorizedResponse();
+    }
+    if (session.tokenType !== "session_token" || !session.sessionId) {
+      return errorResponse(400, "Only session tokens can be refreshed; API keys do not expire");
+    }
+
+    const client = await clerkClient();
+    const token = await client.sessions.getToken(session.sessionId);
+    return NextResponse.json({ token: token.jwt });
+  } catch (error) {
+    return caughtErrorResponse(error, "Token refresh failed");
+  }
+}
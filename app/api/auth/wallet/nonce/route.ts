@@ -0,0 +1,75 @@
+/// app/api/auth/wallet/nonce/route.ts — Issue a Sign-In with Ethereum nonce
+/// Returns the fields the client puts in the EIP-4361 message it signs
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import { isAddress } from "viem";
+import { api } from "../../../../../convex/_generated/api";
+import { WALLET_LOGIN_STATEMENT } from "../../../../../lib/walletLogin";
+import { errorResponse, caughtErrorResponse } from "../../../../../lib/apiErrors";
+import { configuredMonadChain } from "../../../../../lib/monad";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  return new ConvexHttpClient(convexUrl);
+}
+
+function getConvexServiceToken() {
+  const token = process.env.CONVEX_SERVICE_TOKEN;
+  if (!token) throw new Error("CONVEX_SERVICE_TOKEN is not set");
+  return token;
+}
+
+// The caller's address, from a header only the proxy in front of the app
+// can set: CLIENT_IP_HEADER, or on Vercel its x-vercel-forwarded-for. A
+// plain x-forwarded-for is whatever the client sent, so it is not used by
+// default. For a list, the last entry is the one the proxy added. Without a
+// trusted header nonces are limited per wallet only.
+function clientIp(request: Request): string | undefined {
+  const header =
+    process.env.CLIENT_IP_HEADER?.trim() ||
+    (process.env.VERCEL ? "x-vercel-forwarded-for" : "");
+  if (!header) return undefined;
+  const value = request.headers.get(header)?.split(",").pop()?.trim();
+  return value || undefined;
+}
+
+export async function POST(request: Request) {
+  try {
+    const body = (await request.json()) as { address?: string };
+    const address = body.address?.trim();
+    if (!address || !isAddress(address)) {
+      return errorResponse(400, "A valid address is required");
+    }
+
+    const convex = getConvexClient();
+    const issued = await convex.mutation(api.walletLogin.issueNonce, {
+      walletAddress: address,
+      clientIp: clientIp(request),
+      serviceToken: getConvexServiceToken(),
+    });
+
+    const url = new URL(request.url);
+    return NextResponse.json({
+      nonce: issued.nonce,
+      domain: url.host,
+      uri: url.origin,
+      statement: WALLET_LOGIN_STATEMENT,
+      chainId: configuredMonadChain().id,
+      issuedAt: new Date(issued.issuedAt).toISOString(),
+      expirationTime: new Date(issued.expiresAt).toISOString(),
+    });
+  } catch (error) {
+    const message = error instanceof Error ? error.message : "Nonce request failed";
+    if (message.includes("Too many sign-in requests")) {
+      return errorResponse(
+        429,
+        "Too many sign-in requests; try again in a few minutes",
+        "rate_limited",
+        { headers: { "Retry-After": "300" } },
+      );
+    }
+    return caughtErrorResponse(error, "Nonce request failed");
+  }
+}
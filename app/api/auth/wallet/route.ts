@@ -0,0 +1,95 @@
+/// app/api/auth/wallet/route.ts — Exchange a signed Sign-In with Ethereum message for an API token
+/// The wallet's user is found (or created, like Telegram sign-ins) and gets a short-lived Clerk API key
+
+import { NextResponse } from "next/server";
+import { clerkClient } from "@clerk/nextjs/server";
+import { ConvexHttpClient } from "convex/browser";
+import { api } from "../../../../convex/_generated/api";
+import { verifyWalletLogin, WALLET_TOKEN_TTL_SECONDS } from "../../../../lib/walletLogin";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
+import { configuredMonadChain } from "../../../../lib/monad";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  return new ConvexHttpClient(convexUrl);
+}
+
+function getConvexServiceToken() {
+  const token = process.env.CONVEX_SERVICE_TOKEN;
+  if (!token) throw new Error("CONVEX_SERVICE_TOKEN is not set");
+  return token;
+}
+
+export async function POST(request: Request) {
+  const body = (await request.json().catch(() => ({}))) as {
+    message?: string;
+    signature?: string;
+  };
+  if (!body.message || !body.signature) {
+    return errorResponse(400, "message and signature are required");
+  }
+
+  const convex = getConvexClient();
+  const serviceToken = getConvexServiceToken();
+
+  let address: `0x${string}`;
+  try {
+    const verified = await verifyWalletLogin(
+      body.message,
+      body.signature,
+      new URL(request.url).host,
+      configuredMonadChain().id,
+    );
+    await convex.mutation(api.walletLogin.consumeNonce, {
+      nonce: verified.nonce,
+      walletAddress: verified.address,
+      serviceToken,
+    });
+    address = verified.address;
+  } catch (error) {
+    return caughtErrorResponse(error, "Invalid sign-in", 401);
+  }
+
+  try {
+    const clerk = await clerkClient();
+    const linked =
+      (await convex.query(api.users.getByWallet, { walletAddress: address, serviceToken })) ??
+      (await convex.query(api.users.getByWallet, {
+        walletAddress: address.toLowerCase(),
+        serviceToken,
+      }));
+
+    let clerkUserId = linked?.clerkId;
+    if (!clerkUserId) {
+      const externalId = `eth:${address.toLowerCase()}`;
+      const existing = await clerk.users.getUserList({
+        externalId: [externalId],
+        limit: 1,
+      });
+      clerkUserId =
+        existing.data[0]?.id ??
+        (await clerk.users.createUser({ externalId, skipLegalChecks: true })).id;
+      await convex.mutation(api.users.upsertByClerkId, {
+        clerkId: clerkUserId,
+        walletAddress: address,
+        serviceToken,
+      });
+    }
+
+    const apiKey = await clerk.apiKeys.create({
+      name: `buddyevents wallet login ${address}`,
+      subject: clerkUserId,
+      secondsUntilExpiration: WALLET_TOKEN_TTL_SECONDS,
+    });
+
+    return NextResponse.json({
+      token: apiKey.secret,
+      expiresAt: apiKey.expiration,
+      clerkId: clerkUserId,
+      walletAddress: address,
+    });
+  } catch (error) {
+    return caughtErrorResponse(error, "Wallet login failed");
+  }
+}
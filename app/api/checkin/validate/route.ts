@@ -2,6 +2,7 @@ import { NextResponse } from "next/server";
 import { ConvexHttpClient } from "convex/browser";
 import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../convex/_generated/api";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -19,13 +20,13 @@ export async function POST(request: Request) {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const body = (await request.json()) as { token?: string };
     const token = body.token?.trim();
     if (!token) {
-      return NextResponse.json({ error: "token is required" }, { status: 400 });
+      return errorResponse(400, "token is required");
     }
 
     const convex = getConvexClient();
@@ -35,10 +36,7 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!user || user.role !== "admin") {
-      return NextResponse.json(
-        { error: "Admin access required" },
-        { status: 403 },
-      );
+      return errorResponse(403, "Admin access required", "admin_required");
     }
 
     const result = await convex.mutation(api.qr.validateAndCheckIn, {
@@ -49,12 +47,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json(result, { status: result.ok ? 200 : 400 });
   } catch (error) {
-    return NextResponse.json(
-      {
-        ok: false,
-        error: error instanceof Error ? error.message : "Check-in failed",
-      },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Check-in failed", 500, { ok: false });
   }
 }
@@ -21,6 +21,7 @@ import {
   MONAD_USDC_ADDRESS,
   PAY_TO_ADDRESS,
 } from "../../../../../lib/x402";
+import { type ApiErrorCode, classifyConvexError } from "../../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -122,6 +123,7 @@ type BuyTicketResponse = {
   eventId: string;
   buyer: string;
   message: string;
+  code?: ApiErrorCode;
   txHash: string | null;
   timestamp: string;
 };
@@ -145,7 +147,9 @@ function jsonWithHeaders(
   status: number,
   headers?: Record<string, string>,
 ) {
-  const response = NextResponse.json(body, { status });
+  // Failures carry a machine code like every other route's error body.
+  const code = body.success ? undefined : classifyConvexError(body.message, status).code;
+  const response = NextResponse.json(code ? { ...body, code } : body, { status });
   if (headers) {
     for (const [key, value] of Object.entries(headers)) {
       response.headers.set(key, value);
@@ -0,0 +1,41 @@
+/// app/api/events/[id]/route.ts — REST API for a single event
+/// GET: full event detail with team, project and sponsors resolved
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import { api } from "../../../../convex/_generated/api";
+import type { Id } from "../../../../convex/_generated/dataModel";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+  return new ConvexHttpClient(convexUrl);
+}
+
+export async function GET(
+  _request: Request,
+  { params }: { params: Promise<{ id: string }> },
+) {
+  const { id } = await params;
+
+  try {
+    const convex = getConvexClient();
+    const detail = await convex.query(api.events.getDetail, {
+      id: id as Id<"events">,
+    });
+    if (!detail) {
+      return errorResponse(404, "Event not found");
+    }
+    return NextResponse.json(detail);
+  } catch (error) {
+    const message = error instanceof Error ? error.message : "Failed to load event";
+    // Convex rejects ids that are not from the events table before the query runs.
+    if (message.includes("ArgumentValidationError")) {
+      return errorResponse(400, "Invalid event ID");
+    }
+    return caughtErrorResponse(error, "Failed to load event");
+  }
+}
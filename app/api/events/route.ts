@@ -1,11 +1,12 @@
 /// app/api/events/route.ts — REST API for events (CLI and agent access)
-/// GET: list events, POST: create event
+/// GET: list, filter and page events, POST: create, edit, link on-chain or cancel an event
 
 import { NextResponse } from "next/server";
 import { ConvexHttpClient } from "convex/browser";
-import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../convex/_generated/api";
+import { getCallerClerkId, unauthorizedResponse } from "../../../lib/apiAuth";
 import type { Id } from "../../../convex/_generated/dataModel";
+import { errorResponse, caughtErrorResponse } from "../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -31,12 +32,6 @@ export async function GET(request: Request) {
   const ticketsQuery = url.searchParams.get("tickets");
   const eventId = url.searchParams.get("eventId");
   const buyer = url.searchParams.get("buyer");
-  const status = url.searchParams.get("status") as
-    | "draft"
-    | "active"
-    | "ended"
-    | "cancelled"
-    | null;
   const moderationStatus = url.searchParams.get("moderationStatus") as
     | "pending"
     | "approved"
@@ -47,16 +42,16 @@ export async function GET(request: Request) {
     const convex = getConvexClient();
     const serviceToken = getConvexServiceToken();
     if (ticketsQuery === "true" && eventId) {
-      const { userId: clerkUserId } = await auth();
+      const clerkUserId = await getCallerClerkId();
       if (!clerkUserId) {
-        return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+        return unauthorizedResponse();
       }
       const caller = await convex.query(api.users.getByClerkId, {
         clerkId: clerkUserId,
         serviceToken,
       });
       if (!caller || caller.role !== "admin") {
-        return NextResponse.json({ error: "Admin access required" }, { status: 403 });
+        return errorResponse(403, "Admin access required", "admin_required");
       }
 
       const tickets = await convex.query(api.tickets.listByEvent, {
@@ -66,19 +61,19 @@ export async function GET(request: Request) {
       return NextResponse.json({ tickets });
     }
     if (ticketsQuery === "true" && buyer) {
-      const { userId: clerkUserId } = await auth();
+      const clerkUserId = await getCallerClerkId();
       if (!clerkUserId) {
-        return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+        return unauthorizedResponse();
       }
       const caller = await convex.query(api.users.getByClerkId, {
         clerkId: clerkUserId,
         serviceToken,
       });
       if (!caller) {
-        return NextResponse.json({ error: "User profile not found" }, { status: 404 });
+        return errorResponse(404, "User profile not found");
       }
       if (caller.role !== "admin" && !isSameAddress(caller.walletAddress, buyer)) {
-        return NextResponse.json({ error: "Forbidden" }, { status: 403 });
+        return errorResponse(403, "Forbidden");
       }
 
       const tickets = await convex.query(api.tickets.listByBuyer, {
@@ -87,24 +82,80 @@ export async function GET(request: Request) {
       });
       return NextResponse.json({ tickets });
     }
-    const events = await convex.query(api.events.list, {
-      status: status ?? undefined,
+    const search = parseSearchParams(url.searchParams);
+    if ("error" in search) {
+      return errorResponse(400, search.error);
+    }
+    const { events, nextCursor } = await convex.query(api.events.search, {
+      ...search.args,
       moderationStatus: moderationStatus ?? undefined,
     });
-    return NextResponse.json({ events });
+    return NextResponse.json(nextCursor ? { events, nextCursor } : { events });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Failed to list events" },
-      { status: 500 },
-    );
+    const message = error instanceof Error ? error.message : "Failed to list events";
+    if (message.includes("ArgumentValidationError")) {
+      return errorResponse(400, "Invalid team or project ID");
+    }
+    if (message.includes("Invalid cursor") || message.includes("Limit must be")) {
+      return errorResponse(400, "Invalid cursor or limit");
+    }
+    return caughtErrorResponse(error, "Failed to list events");
   }
 }
 
+const eventStatuses = ["draft", "active", "ended", "cancelled"] as const;
+const eventSorts = ["created", "start", "price", "popularity"] as const;
+
+// Reads the list filters: status (comma-separated), team, project, from and
+// to (unix ms), maxPrice, hasSeats, location, q, sort, order, limit, cursor.
+function parseSearchParams(params: URLSearchParams) {
+  const number = (name: string) => {
+    const raw = params.get(name);
+    if (raw === null || raw === "") return undefined;
+    const value = Number(raw);
+    return Number.isFinite(value) ? value : NaN;
+  };
+  const args = {
+    statuses: params.get("status")?.split(",").filter(Boolean) as
+      | (typeof eventStatuses)[number][]
+      | undefined,
+    teamId: (params.get("team") || undefined) as Id<"teams"> | undefined,
+    projectId: (params.get("project") || undefined) as Id<"projects"> | undefined,
+    from: number("from"),
+    to: number("to"),
+    maxPrice: number("maxPrice"),
+    hasSeats: params.get("hasSeats") === "true" ? true : undefined,
+    location: params.get("location") || undefined,
+    text: params.get("q") || undefined,
+    sort: (params.get("sort") || undefined) as (typeof eventSorts)[number] | undefined,
+    order: (params.get("order") || undefined) as "asc" | "desc" | undefined,
+    limit: number("limit"),
+    cursor: params.get("cursor") || undefined,
+  };
+
+  for (const name of ["from", "to", "maxPrice", "limit"] as const) {
+    if (Number.isNaN(args[name])) return { error: `Invalid ${name}: want a number` };
+  }
+  if (args.limit !== undefined && (!Number.isInteger(args.limit) || args.limit < 1 || args.limit > 500)) {
+    return { error: "Invalid limit: want 1 to 500" };
+  }
+  if (args.statuses?.some((status) => !eventStatuses.includes(status))) {
+    return { error: `Invalid status: want ${eventStatuses.join(", ")}` };
+  }
+  if (args.sort && !eventSorts.includes(args.sort)) {
+    return { error: `Invalid sort: want ${eventSorts.join(", ")}` };
+  }
+  if (args.order && args.order !== "asc" && args.order !== "desc") {
+    return { error: "Invalid order: want asc or desc" };
+  }
+  return { args };
+}
+
 export async function POST(request: Request) {
   try {
-    const { userId: clerkUserId } = await auth();
+    const clerkUserId = await getCallerClerkId();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return unauthorizedResponse();
     }
 
     const convex = getConvexClient();
@@ -114,7 +165,7 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!caller || caller.role !== "admin") {
-      return NextResponse.json({ error: "Admin access required" }, { status: 403 });
+      return errorResponse(403, "Admin access required", "admin_required");
     }
 
     const body = await request.json();
@@ -125,6 +176,32 @@ export async function POST(request: Request) {
       });
       return NextResponse.json({ ok: true });
     }
+    if (body.action === "edit") {
+      await convex.mutation(api.events.edit, {
+        id: body.eventId as Id<"events">,
+        name: body.name,
+        description: body.description,
+        startTime: body.startTime,
+        endTime: body.endTime,
+        price: body.price,
+        maxTickets: body.maxTickets,
+        location: body.location,
+        teamId: body.teamId as Id<"teams"> | undefined,
+        projectId: body.projectId as Id<"projects"> | undefined,
+        sponsors: body.sponsors as Id<"sponsors">[] | undefined,
+        serviceToken,
+      });
+      return NextResponse.json({ ok: true });
+    }
+    if (body.action === "link") {
+      await convex.mutation(api.events.setOnChainData, {
+        id: body.eventId as Id<"events">,
+        onChainEventId: body.onChainEventId,
+        contractAddress: body.contractAddress,
+        serviceToken,
+      });
+      return NextResponse.json({ ok: true });
+    }
 
     const eventId = await convex.mutation(api.events.create, {
       name: body.name,
@@ -134,6 +211,7 @@ export async function POST(request: Request) {
       price: body.price,
       maxTickets: body.maxTickets,
       teamId: body.teamId,
+      projectId: body.projectId,
       sponsors: body.sponsors ?? [],
       location: body.location ?? "",
       creatorAddress: body.creatorAddress,
@@ -142,9 +220,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json({ eventId }, { status: 201 });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Failed to create event" },
-      { status: 400 },
-    );
+    return caughtErrorResponse(error, "Failed to create event", 400);
   }
 }
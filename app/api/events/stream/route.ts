@@ -0,0 +1,67 @@
+/// app/api/events/stream/route.ts — Live event list over server-sent events
+/// GET: subscribes to api.events.list and pushes a snapshot on every change (used by `events watch`)
+
+import { ConvexClient } from "convex/browser";
+import { api } from "../../../../convex/_generated/api";
+import { errorResponse } from "../../../../lib/apiErrors";
+
+export const dynamic = "force-dynamic";
+
+// Comment lines keep proxies from closing an idle stream.
+const HEARTBEAT_MS = 25_000;
+
+export async function GET(request: Request) {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    return errorResponse(500, "NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+
+  const encoder = new TextEncoder();
+  const convex = new ConvexClient(convexUrl);
+  let cleanup = () => {};
+
+  const stream = new ReadableStream<Uint8Array>({
+    start(controller) {
+      let closed = false;
+      const write = (text: string) => {
+        if (!closed) controller.enqueue(encoder.encode(text));
+      };
+      const send = (event: string, data: unknown) => {
+        write(`event: ${event}\ndata: ${JSON.stringify(data)}\n\n`);
+      };
+
+      const unsubscribe = convex.onUpdate(
+        api.events.list,
+        {},
+        (events) => send("snapshot", { events }),
+        (error) => {
+          send("error", { error: error.message });
+          cleanup();
+        },
+      );
+      const heartbeat = setInterval(() => write(": ping\n\n"), HEARTBEAT_MS);
+
+      cleanup = () => {
+        if (closed) return;
+        closed = true;
+        clearInterval(heartbeat);
+        unsubscribe();
+        void convex.close();
+        controller.close();
+      };
+      request.signal.addEventListener("abort", () => cleanup());
+    },
+    cancel() {
+      cleanup();
+    },
+  });
+
+  return new Response(stream, {
+    headers: {
+      "Content-Type": "text/event-stream; charset=utf-8",
+      "Cache-Control": "no-cache, no-transform",
+      Connection: "keep-alive",
+      "X-Accel-Buffering": "no",
+    },
+  });
+}
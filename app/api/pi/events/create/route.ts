@@ -3,6 +3,7 @@ import { ConvexHttpClient } from "convex/browser";
 import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../../convex/_generated/api";
 import { executePiAction } from "../../../../../lib/piAgent";
+import { errorResponse, caughtErrorResponse } from "../../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -20,7 +21,7 @@ export async function POST(request: Request) {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const convex = getConvexClient();
@@ -30,10 +31,7 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!user || user.role !== "admin") {
-      return NextResponse.json(
-        { error: "Admin access required" },
-        { status: 403 },
-      );
+      return errorResponse(403, "Admin access required", "admin_required");
     }
 
     const args = (await request.json()) as Record<string, unknown>;
@@ -47,9 +45,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json(result, { status: result.ok ? 201 : 400 });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Event creation failed" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Event creation failed");
   }
 }
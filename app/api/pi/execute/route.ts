@@ -4,6 +4,7 @@ import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../convex/_generated/api";
 import type { Id } from "../../../../convex/_generated/dataModel";
 import { executePiAction, type PiIntent, type PiSource } from "../../../../lib/piAgent";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -28,10 +29,7 @@ export async function POST(request: Request) {
 
     const rawInput = body.rawInput?.trim() ?? "";
     if (!rawInput) {
-      return NextResponse.json(
-        { error: "rawInput is required" },
-        { status: 400 },
-      );
+      return errorResponse(400, "rawInput is required");
     }
 
     const { userId: clerkUserId } = await auth();
@@ -63,12 +61,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json(result, { status: result.ok ? 200 : 400 });
   } catch (error) {
-    return NextResponse.json(
-      {
-        ok: false,
-        error: error instanceof Error ? error.message : "Execution failed",
-      },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Execution failed", 500, { ok: false });
   }
 }
@@ -3,6 +3,7 @@ import { ConvexHttpClient } from "convex/browser";
 import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../convex/_generated/api";
 import type { Id } from "../../../../convex/_generated/dataModel";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -25,13 +26,13 @@ export async function GET(request: Request) {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const url = new URL(request.url);
     const ticketId = url.searchParams.get("ticketId");
     if (!ticketId) {
-      return NextResponse.json({ error: "ticketId is required" }, { status: 400 });
+      return errorResponse(400, "ticketId is required");
     }
 
     const convex = getConvexClient();
@@ -41,7 +42,7 @@ export async function GET(request: Request) {
       serviceToken,
     });
     if (!user) {
-      return NextResponse.json({ error: "User profile not found" }, { status: 404 });
+      return errorResponse(404, "User profile not found");
     }
 
     const ticket = await convex.query(api.tickets.get, {
@@ -49,7 +50,7 @@ export async function GET(request: Request) {
       serviceToken,
     });
     if (!ticket) {
-      return NextResponse.json({ error: "Ticket not found" }, { status: 404 });
+      return errorResponse(404, "Ticket not found");
     }
 
     const linkedWallet = await convex.query(api.wallets.getByUser, {
@@ -60,10 +61,7 @@ export async function GET(request: Request) {
       !sameAddress(user.walletAddress ?? undefined, ticket.buyerAddress) &&
       !sameAddress(linkedWallet?.walletAddress, ticket.buyerAddress)
     ) {
-      return NextResponse.json(
-        { error: "You do not own this ticket" },
-        { status: 403 },
-      );
+      return errorResponse(403, "You do not own this ticket");
     }
 
     const issued = await convex.mutation(api.qr.issueForTicket, {
@@ -75,9 +73,6 @@ export async function GET(request: Request) {
 
     return NextResponse.json({ ok: true, qr: issued });
   } catch (error) {
-    return NextResponse.json(
-      { ok: false, error: error instanceof Error ? error.message : "Failed to load QR" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Failed to load QR", 500, { ok: false });
   }
 }
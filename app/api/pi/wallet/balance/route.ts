@@ -3,6 +3,7 @@ import { ConvexHttpClient } from "convex/browser";
 import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../../convex/_generated/api";
 import { getWalletBalance, getCircleConfigForServer } from "../../../../../lib/circle";
+import { errorResponse, caughtErrorResponse } from "../../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -20,7 +21,7 @@ export async function GET() {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const convex = getConvexClient();
@@ -30,10 +31,7 @@ export async function GET() {
       serviceToken,
     });
     if (!user) {
-      return NextResponse.json(
-        { error: "No profile found. Connect wallet first." },
-        { status: 400 },
-      );
+      return errorResponse(400, "No profile found. Connect wallet first.");
     }
 
     const wallet = await convex.query(api.wallets.getByUser, {
@@ -41,22 +39,13 @@ export async function GET() {
       serviceToken,
     });
     if (!wallet) {
-      return NextResponse.json(
-        { error: "No linked Circle wallet found." },
-        { status: 404 },
-      );
+      return errorResponse(404, "No linked Circle wallet found.");
     }
 
     const config = getCircleConfigForServer();
     const balances = await getWalletBalance(config.apiKey, wallet.walletId);
     return NextResponse.json({ ok: true, wallet, balances });
   } catch (error) {
-    return NextResponse.json(
-      {
-        ok: false,
-        error: error instanceof Error ? error.message : "Failed to fetch balance",
-      },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Failed to fetch balance", 500, { ok: false });
   }
 }
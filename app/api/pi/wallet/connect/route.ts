@@ -3,6 +3,7 @@ import { ConvexHttpClient } from "convex/browser";
 import { auth, clerkClient } from "@clerk/nextjs/server";
 import { api } from "../../../../../convex/_generated/api";
 import { createOrGetCircleWalletForUser } from "../../../../../lib/circle";
+import { errorResponse, caughtErrorResponse } from "../../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -20,7 +21,7 @@ export async function POST() {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const convex = getConvexClient();
@@ -44,9 +45,6 @@ export async function POST() {
     const wallet = await createOrGetCircleWalletForUser(convex, user._id);
     return NextResponse.json({ ok: true, wallet });
   } catch (error) {
-    return NextResponse.json(
-      { ok: false, error: error instanceof Error ? error.message : "Wallet link failed" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Wallet link failed", 500, { ok: false });
   }
 }
@@ -0,0 +1,25 @@
+/// app/api/projects/route.ts — Project listing API
+/// GET: list projects (used to resolve project names in event manifests)
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import { api } from "../../../convex/_generated/api";
+import { caughtErrorResponse } from "../../../lib/apiErrors";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+  return new ConvexHttpClient(convexUrl);
+}
+
+export async function GET() {
+  try {
+    const convex = getConvexClient();
+    const projects = await convex.query(api.projects.listAll, {});
+    return NextResponse.json({ projects });
+  } catch (error) {
+    return caughtErrorResponse(error, "Failed to list projects");
+  }
+}
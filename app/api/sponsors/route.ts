@@ -0,0 +1,25 @@
+/// app/api/sponsors/route.ts — Sponsor listing API
+/// GET: list sponsors (used to resolve sponsor names in event manifests)
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import { api } from "../../../convex/_generated/api";
+import { caughtErrorResponse } from "../../../lib/apiErrors";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+  return new ConvexHttpClient(convexUrl);
+}
+
+export async function GET() {
+  try {
+    const convex = getConvexClient();
+    const sponsors = await convex.query(api.sponsors.list, {});
+    return NextResponse.json({ sponsors });
+  } catch (error) {
+    return caughtErrorResponse(error, "Failed to list sponsors");
+  }
+}
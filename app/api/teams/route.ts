@@ -3,8 +3,9 @@
 
 import { NextResponse } from "next/server";
 import { ConvexHttpClient } from "convex/browser";
-import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../convex/_generated/api";
+import { getCallerClerkId, unauthorizedResponse } from "../../../lib/apiAuth";
+import { errorResponse, caughtErrorResponse } from "../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -26,18 +27,15 @@ export async function GET() {
     const teams = await convex.query(api.teams.list, {});
     return NextResponse.json({ teams });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Failed to list teams" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Failed to list teams");
   }
 }
 
 export async function POST(request: Request) {
   try {
-    const { userId: clerkUserId } = await auth();
+    const clerkUserId = await getCallerClerkId();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return unauthorizedResponse();
     }
 
     const convex = getConvexClient();
@@ -47,7 +45,7 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!caller || caller.role !== "admin") {
-      return NextResponse.json({ error: "Admin access required" }, { status: 403 });
+      return errorResponse(403, "Admin access required", "admin_required");
     }
 
     const body = await request.json();
@@ -61,9 +59,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json({ teamId }, { status: 201 });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Failed to create team" },
-      { status: 400 },
-    );
+    return caughtErrorResponse(error, "Failed to create team", 400);
   }
 }
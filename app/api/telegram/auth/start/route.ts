@@ -3,6 +3,7 @@ import { clerkClient } from "@clerk/nextjs/server";
 import { ConvexHttpClient } from "convex/browser";
 import { api } from "../../../../../convex/_generated/api";
 import { readTelegramInitData, verifyTelegramInitData } from "../../../../../lib/telegramAuth";
+import { errorResponse, caughtErrorResponse } from "../../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -24,22 +25,16 @@ export async function POST(request: Request) {
     };
     const initData = body.initData?.trim();
     if (!initData) {
-      return NextResponse.json({ error: "initData is required" }, { status: 400 });
+      return errorResponse(400, "initData is required");
     }
 
     const botToken = process.env.TELEGRAM_BOT_TOKEN;
     if (!botToken) {
-      return NextResponse.json(
-        { error: "TELEGRAM_BOT_TOKEN missing on server" },
-        { status: 500 },
-      );
+      return errorResponse(500, "TELEGRAM_BOT_TOKEN missing on server");
     }
 
     if (!verifyTelegramInitData(initData, botToken)) {
-      return NextResponse.json(
-        { error: "Invalid Telegram init data signature" },
-        { status: 401 },
-      );
+      return errorResponse(401, "Invalid Telegram init data signature");
     }
 
     const parsed = readTelegramInitData(initData, 10 * 60);
@@ -108,12 +103,6 @@ export async function POST(request: Request) {
       expiresInSeconds: 300,
     });
   } catch (error) {
-    return NextResponse.json(
-      {
-        ok: false,
-        error: error instanceof Error ? error.message : "Auth start failed",
-      },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Auth start failed", 500, { ok: false });
   }
 }
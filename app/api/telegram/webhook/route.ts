@@ -7,6 +7,7 @@ import {
   sendTelegramMessage,
   verifyTelegramWebhookSecret,
 } from "../../../../lib/telegram";
+import { errorResponse } from "../../../../lib/apiErrors";
 
 type TelegramInlineKeyboardButton = {
   text: string;
@@ -573,7 +574,7 @@ async function maybeGenerateLlmReply(args: {
 export async function POST(request: Request) {
   const secretHeader = request.headers.get("x-telegram-bot-api-secret-token");
   if (!verifyTelegramWebhookSecret(secretHeader)) {
-    return NextResponse.json({ error: "Invalid webhook secret" }, { status: 401 });
+    return errorResponse(401, "Invalid webhook secret");
   }
 
   const update = (await request.json()) as TelegramUpdate;
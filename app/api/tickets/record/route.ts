@@ -0,0 +1,221 @@
+/// app/api/tickets/record/route.ts — Record a direct on-chain purchase in Convex
+/// Verifies the buyTicket receipt + buyer signature, then issues ticket + QR
+
+import { NextResponse } from "next/server";
+import { ConvexHttpClient } from "convex/browser";
+import {
+  createPublicClient,
+  http,
+  isAddress,
+  isHash,
+  parseEventLogs,
+  verifyMessage,
+  type Hex,
+} from "viem";
+import { api } from "../../../../convex/_generated/api";
+import type { Doc, Id } from "../../../../convex/_generated/dataModel";
+import {
+  BUDDY_EVENTS_ABI,
+  CLAIM_MAX_AGE_MS,
+  CLAIM_MAX_SKEW_MS,
+  configuredMonadChain,
+  onChainPurchaseClaimMessage,
+} from "../../../../lib/monad";
+import {
+  type ApiErrorCode,
+  classifyConvexError,
+  codeForStatus,
+  errorResponse,
+} from "../../../../lib/apiErrors";
+
+function getConvexClient() {
+  const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
+  if (!convexUrl) {
+    throw new Error("NEXT_PUBLIC_CONVEX_URL is not set");
+  }
+  return new ConvexHttpClient(convexUrl);
+}
+
+function getConvexServiceToken() {
+  const token = process.env.CONVEX_SERVICE_TOKEN;
+  if (!token) throw new Error("CONVEX_SERVICE_TOKEN is not set");
+  return token;
+}
+
+function isSameAddress(a: string | undefined, b: string | undefined): boolean {
+  if (!a || !b) return false;
+  return a.toLowerCase() === b.toLowerCase();
+}
+
+const chain = configuredMonadChain();
+const publicClient = createPublicClient({
+  chain,
+  transport: http(chain.rpcUrls.default.http[0]),
+});
+
+type RecordPurchaseResponse = {
+  success: boolean;
+  ticketId: string | null;
+  qrCode: string | null;
+  eventId: string | null;
+  buyer: string;
+  tokenId: string | null;
+  txHash: string;
+  alreadyRecorded: boolean;
+  message: string;
+};
+
+function fail(
+  status: number,
+  message: string,
+  partial: Partial<RecordPurchaseResponse> = {},
+  code: ApiErrorCode = codeForStatus(status),
+) {
+  const body: RecordPurchaseResponse = {
+    success: false,
+    ticketId: null,
+    qrCode: null,
+    eventId: null,
+    buyer: "",
+    tokenId: null,
+    txHash: "",
+    alreadyRecorded: false,
+    message,
+    ...partial,
+  };
+  return errorResponse(status, message, code, { extra: body });
+}
+
+export async function POST(request: Request) {
+  let body: Record<string, unknown>;
+  try {
+    body = await request.json();
+  } catch {
+    return fail(400, "Invalid JSON body");
+  }
+
+  const txHash = typeof body.txHash === "string" ? body.txHash.trim().toLowerCase() : "";
+  const buyer = typeof body.buyerAddress === "string" ? body.buyerAddress.trim() : "";
+  const signature = typeof body.signature === "string" ? body.signature.trim() : "";
+  const issuedAt = typeof body.issuedAt === "string" ? body.issuedAt.trim() : "";
+  const requestedEventId = typeof body.eventId === "string" ? body.eventId.trim() : "";
+  const buyerAgentId = typeof body.agentId === "string" && body.agentId ? body.agentId : undefined;
+  const partial = { txHash, buyer };
+
+  if (!isHash(txHash)) return fail(400, "txHash must be a 32-byte hex hash", partial);
+  if (!isAddress(buyer)) return fail(400, "buyerAddress must be a valid wallet address", partial);
+  if (!signature) return fail(400, "signature is required", partial);
+  const claimIssuedAt = Date.parse(issuedAt);
+  if (!issuedAt || Number.isNaN(claimIssuedAt)) {
+    return fail(400, "issuedAt must be an RFC 3339 timestamp", partial);
+  }
+  const claimAge = Date.now() - claimIssuedAt;
+  if (claimAge > CLAIM_MAX_AGE_MS || claimAge < -CLAIM_MAX_SKEW_MS) {
+    return fail(401, "Claim expired or issued in the future; sign a new one", partial);
+  }
+
+  try {
+    const receipt = await publicClient.getTransactionReceipt({ hash: txHash as Hex });
+    if (receipt.status !== "success") {
+      return fail(400, "Transaction reverted", partial);
+    }
+
+    const purchase = parseEventLogs({
+      abi: BUDDY_EVENTS_ABI,
+      eventName: "TicketPurchased",
+      logs: receipt.logs,
+    }).find((log) => isSameAddress(log.args.buyer, buyer));
+    if (!purchase) {
+      return fail(400, "No TicketPurchased log for this buyer in transaction", partial);
+    }
+
+    const tokenId = purchase.args.tokenId.toString();
+    if (body.tokenId !== undefined && String(body.tokenId) !== tokenId) {
+      return fail(400, `tokenId mismatch: transaction minted ${tokenId}`, partial);
+    }
+
+    const signatureValid = await verifyMessage({
+      address: buyer as Hex,
+      message: onChainPurchaseClaimMessage(txHash, tokenId, issuedAt),
+      signature: signature as Hex,
+    });
+    if (!signatureValid) {
+      return fail(401, "Signature does not match buyerAddress", { ...partial, tokenId });
+    }
+
+    const convex = getConvexClient();
+    const serviceToken = getConvexServiceToken();
+
+    let event: Doc<"events"> | null;
+    if (requestedEventId) {
+      event = await convex.query(api.events.get, { id: requestedEventId as Id<"events"> });
+    } else {
+      event = await convex.query(api.events.getByOnChainEventId, {
+        onChainEventId: Number(purchase.args.eventId),
+        contractAddress: purchase.address,
+      });
+    }
+    if (!event) {
+      return fail(404, "Event not found", { ...partial, tokenId });
+    }
+
+    // Only a linked event says which contract and on-chain event a ticket
+    // must come from; without both, any purchase could be claimed for it.
+    if (event.onChainEventId === undefined || !event.contractAddress) {
+      return fail(400, "Event is not linked to an on-chain event", { ...partial, tokenId });
+    }
+    if (!isSameAddress(purchase.address, event.contractAddress)) {
+      return fail(400, "Transaction is not from the event's contract", { ...partial, tokenId });
+    }
+    if (BigInt(event.onChainEventId) !== purchase.args.eventId) {
+      return fail(400, "Transaction bought a ticket for a different event", {
+        ...partial,
+        tokenId,
+      });
+    }
+
+    // A resold token belongs to someone else now; only its current owner
+    // may claim (or re-claim) the entry QR.
+    const owner = await publicClient.readContract({
+      address: event.contractAddress as Hex,
+      abi: BUDDY_EVENTS_ABI,
+      functionName: "ownerOf",
+      args: [purchase.args.tokenId],
+    });
+    if (!isSameAddress(owner, buyer)) {
+      return fail(403, "Ticket has been transferred; only its current owner can claim it", {
+        ...partial,
+        tokenId,
+      });
+    }
+
+    const result = await convex.mutation(api.tickets.recordOnChainPurchase, {
+      eventId: event._id,
+      tokenId: Number(purchase.args.tokenId),
+      buyerAddress: buyer,
+      buyerAgentId,
+      txHash,
+      claimIssuedAt,
+      serviceToken,
+    });
+
+    const response: RecordPurchaseResponse = {
+      success: true,
+      ticketId: result.ticketId,
+      qrCode: result.qrToken,
+      eventId: event._id,
+      buyer,
+      tokenId,
+      txHash,
+      alreadyRecorded: result.alreadyRecorded,
+      message: result.alreadyRecorded
+        ? "Purchase already recorded; issued a fresh QR token"
+        : "On-chain purchase recorded",
+    };
+    return NextResponse.json(response, { status: result.alreadyRecorded ? 200 : 201 });
+  } catch (error) {
+    const message = error instanceof Error ? error.message : "Failed to record purchase";
+    const { status, code } = classifyConvexError(message, 500);
+    return fail(status, message, partial, code);
+  }
+}
@@ -4,6 +4,7 @@ import { NextResponse } from "next/server";
 import { ConvexHttpClient } from "convex/browser";
 import { auth } from "@clerk/nextjs/server";
 import { api } from "../../../../convex/_generated/api";
+import { errorResponse, caughtErrorResponse } from "../../../../lib/apiErrors";
 
 function getConvexClient() {
   const convexUrl = process.env.NEXT_PUBLIC_CONVEX_URL;
@@ -28,7 +29,7 @@ export async function POST(request: Request) {
   try {
     const { userId: clerkUserId } = await auth();
     if (!clerkUserId) {
-      return NextResponse.json({ error: "Unauthorized" }, { status: 401 });
+      return errorResponse(401, "Unauthorized");
     }
 
     const body = await request.json();
@@ -39,10 +40,7 @@ export async function POST(request: Request) {
         : "";
 
     if (!qrCode) {
-      return NextResponse.json(
-        { error: "qrCode is required" },
-        { status: 400 },
-      );
+      return errorResponse(400, "qrCode is required");
     }
 
     const convex = getConvexClient();
@@ -52,14 +50,14 @@ export async function POST(request: Request) {
       serviceToken,
     });
     if (!user) {
-      return NextResponse.json({ error: "User profile not found" }, { status: 404 });
+      return errorResponse(404, "User profile not found");
     }
     if (
       user.role !== "admin" &&
       requestedOrganizerAddress &&
       !isSameAddress(user.walletAddress, requestedOrganizerAddress)
     ) {
-      return NextResponse.json({ error: "Forbidden" }, { status: 403 });
+      return errorResponse(403, "Forbidden");
     }
 
     const result = await convex.mutation(api.tickets.scanForCheckIn, {
@@ -70,9 +68,6 @@ export async function POST(request: Request) {
 
     return NextResponse.json(result, { status: 200 });
   } catch (error) {
-    return NextResponse.json(
-      { error: error instanceof Error ? error.message : "Scan failed" },
-      { status: 500 },
-    );
+    return caughtErrorResponse(error, "Scan failed");
   }
 }
@@ -2,10 +2,9 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
-	"buddyevents/internal/api"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -25,23 +24,24 @@ var agentRegisterCmd = &cobra.Command{
 		owner, _ := cmd.Flags().GetString("owner")
 
 		if wallet == "" {
-			wallet = cfg.WalletAddress
+			wallet = walletAddress()
 		}
 		if wallet == "" {
 			return fmt.Errorf("no wallet address. Run: buddyevents wallet setup")
 		}
 
-		client := api.NewClient(cfg.APIURL)
-		agentID, err := client.RegisterAgent(name, wallet, owner)
+		agentID, err := apiClient().RegisterAgent(cmd.Context(), name, wallet, owner)
 		if err != nil {
 			return fmt.Errorf("registration failed: %w", err)
 		}
 
-		fmt.Printf("Agent registered!\n")
-		fmt.Printf("ID:      %s\n", agentID)
-		fmt.Printf("Name:    %s\n", name)
-		fmt.Printf("Wallet:  %s\n", wallet)
-		return nil
+		return printResult(sdk.Agent{
+			ID:            agentID,
+			Name:          name,
+			WalletAddress: wallet,
+			OwnerAddress:  owner,
+			Status:        sdk.AgentActive,
+		}, agentColumns...)
 	},
 }
 
@@ -52,21 +52,21 @@ var agentInfoCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		wallet, _ := cmd.Flags().GetString("wallet")
 		if wallet == "" {
-			wallet = cfg.WalletAddress
+			wallet = walletAddress()
 		}
 
-		client := api.NewClient(cfg.APIURL)
-		agent, err := client.GetAgent(wallet)
+		agent, err := apiClient().GetAgent(cmd.Context(), wallet)
 		if err != nil {
 			return fmt.Errorf("lookup failed: %w", err)
 		}
 
-		out, _ := json.MarshalIndent(agent, "", "  ")
-		fmt.Println(string(out))
-		return nil
+		return printResult(agent, agentColumns...)
 	},
 }
 
+// agentColumns are the table columns for agents.
+var agentColumns = []string{"_id", "name", "walletAddress", "ownerAddress", "status"}
+
 func init() {
 	agentRegisterCmd.Flags().String("name", "", "Agent name (required)")
 	agentRegisterCmd.Flags().String("wallet", "", "Agent wallet address (defaults to config)")
@@ -0,0 +1,736 @@
+// / cli/cmd/apply.go — Declarative event manifests
+// / events apply -f: diff YAML/JSON manifests against live events, then create, edit and deploy them.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ===== events apply =====
+var eventsApplyCmd = &cobra.Command{
+	Use:   "apply -f <manifest>...",
+	Short: "Create or update events from YAML or JSON manifests",
+	Long: `Make events match their manifests. Each manifest is compared with the live
+event, found by id or else by name within its team; a missing event is
+created and a differing one is edited. Fields left out of a manifest are left
+as they are. Team, project and sponsors may be given by ID or by name.
+
+With onChain: true the event is also deployed with createEvent and linked in
+Convex; name and price edits of a linked event are sent to the contract first,
+with the configured wallet as organizer.
+
+  name: BuddyEvents Rome
+  team: Monad Italia
+  start: 2026-11-03 18:00 Europe/Rome
+  duration: 3h
+  price: 5
+  maxTickets: 120
+  sponsors: [Monad Foundation]
+  onChain: true
+
+-f takes files, directories of .yaml, .yml and .json files, or - for stdin;
+a YAML file may hold several manifests separated by ---.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, _ := cmd.Flags().GetStringArray("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		manifests, err := readManifests(paths, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		if len(manifests) == 0 {
+			return usageError{fmt.Errorf("no manifests found in %s", strings.Join(paths, ", "))}
+		}
+
+		ctx := cmd.Context()
+		refs := &refResolver{client: apiClient()}
+		results := make([]applyResult, 0, len(manifests))
+		columns := []string{"source", "name", "eventId", "action", "changes", "onChain.action", "onChain.eventId", "onChain.txHash"}
+		// Manifests before a failure are already applied, so report them
+		// and the failing one before returning its error.
+		fail := func(result applyResult, err error) error {
+			result.Action = "failed"
+			result.Error = err.Error()
+			results = append(results, result)
+			if perr := printResult(results, append(columns, "error")...); perr != nil {
+				return perr
+			}
+			return fmt.Errorf("%s: %w", result.Source, err)
+		}
+		for _, m := range manifests {
+			plan, err := planManifest(ctx, refs, m)
+			if err != nil {
+				return fail(applyResult{Source: m.source, Name: m.Name, EventID: m.ID}, err)
+			}
+			if !dryRun {
+				if err := plan.apply(ctx); err != nil {
+					return fail(plan.result, err)
+				}
+			}
+			results = append(results, plan.result)
+		}
+		return printResult(results, columns...)
+	},
+}
+
+// eventManifest is the desired state of one event. Nil fields are not
+// managed by the manifest.
+type eventManifest struct {
+	ID          string    `yaml:"id"`
+	Name        string    `yaml:"name"`
+	Description *string   `yaml:"description"`
+	Start       string    `yaml:"start"`
+	End         string    `yaml:"end"`
+	Duration    string    `yaml:"duration"`
+	Timezone    string    `yaml:"timezone"` // zone for start and end; defaults to --timezone
+	Location    *string   `yaml:"location"`
+	Price       *float64  `yaml:"price"` // USDC
+	MaxTickets  *int      `yaml:"maxTickets"`
+	Team        string    `yaml:"team"`    // ID or name
+	Project     *string   `yaml:"project"` // ID or name
+	Sponsors    *[]string `yaml:"sponsors"`
+	OnChain     bool      `yaml:"onChain"`
+
+	source string // file, with #n for the nth document of a multi-document file
+}
+
+// applyResult reports what events apply did, or would do, to one event.
+type applyResult struct {
+	Source  string        `json:"source"`
+	Name    string        `json:"name"`
+	EventID string        `json:"eventId"` // empty when a dry run would create the event
+	Action  string        `json:"action"`  // create, update, unchanged, or failed
+	Changes []fieldChange `json:"changes"`
+	OnChain *onChainApply `json:"onChain"`         // null when the contract is not touched
+	Error   string        `json:"error,omitempty"` // why the manifest failed
+}
+
+// fieldChange is one field that differs between the live event and its
+// manifest.
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func (c fieldChange) String() string {
+	if c.From == "" {
+		return c.Field + ": " + c.To
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Field, c.From, c.To)
+}
+
+// onChainApply is the contract side of an apply: a deploy with createEvent
+// or an editEvent. TxHash is empty in dry runs.
+type onChainApply struct {
+	Action  string `json:"action"`  // deploy or edit
+	EventID string `json:"eventId"` // on-chain ID; empty until deployed
+	TxHash  string `json:"txHash"`
+	Block   uint64 `json:"block"`
+}
+
+// readManifests decodes every manifest in paths, in order. Directories
+// contribute their .yaml, .yml and .json files sorted by name.
+func readManifests(paths []string, stdin io.Reader) ([]eventManifest, error) {
+	var manifests []eventManifest
+	for _, path := range paths {
+		if path == "-" {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("read stdin: %w", err)
+			}
+			found, err := decodeManifests("stdin", data)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, found...)
+			continue
+		}
+
+		files := []string{path}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			files = files[:0]
+			for _, entry := range entries {
+				switch strings.ToLower(filepath.Ext(entry.Name())) {
+				case ".yaml", ".yml", ".json":
+					if !entry.IsDir() {
+						files = append(files, filepath.Join(path, entry.Name()))
+					}
+				}
+			}
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			found, err := decodeManifests(file, data)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, found...)
+		}
+	}
+	return manifests, nil
+}
+
+// decodeManifests reads the YAML documents in data; JSON is read as YAML.
+// Unknown keys are errors so that typos are not silently ignored.
+func decodeManifests(source string, data []byte) ([]eventManifest, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var manifests []eventManifest
+	for doc := 1; ; doc++ {
+		var m eventManifest
+		err := dec.Decode(&m)
+		if errors.Is(err, io.EOF) {
+			return manifests, nil
+		}
+		name := source
+		if doc > 1 {
+			name = fmt.Sprintf("%s#%d", source, doc)
+		}
+		if err != nil {
+			return nil, usageError{fmt.Errorf("%s: %w", name, err)}
+		}
+		if reflect.ValueOf(m).IsZero() {
+			continue // empty document, e.g. a leading ---
+		}
+		if m.Name == "" {
+			return nil, usageError{fmt.Errorf("%s: name is required", name)}
+		}
+		m.source = name
+		manifests = append(manifests, m)
+	}
+}
+
+// refResolver maps team, project and sponsor names to IDs, listing each
+// kind at most once.
+type refResolver struct {
+	client   *sdk.Client
+	teams    []sdk.Team
+	projects []sdk.Project
+	sponsors []sdk.Sponsor
+}
+
+func (r *refResolver) team(ctx context.Context, ref string) (string, error) {
+	if r.teams == nil {
+		teams, err := r.client.ListTeams(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list teams: %w", err)
+		}
+		r.teams = teams
+	}
+	return resolveRef("team", ref, r.teams, func(t sdk.Team) (string, string) { return t.ID, t.Name })
+}
+
+func (r *refResolver) project(ctx context.Context, ref string) (string, error) {
+	if r.projects == nil {
+		projects, err := r.client.ListProjects(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list projects: %w", err)
+		}
+		r.projects = projects
+	}
+	return resolveRef("project", ref, r.projects, func(p sdk.Project) (string, string) { return p.ID, p.Name })
+}
+
+func (r *refResolver) sponsor(ctx context.Context, ref string) (string, error) {
+	if r.sponsors == nil {
+		sponsors, err := r.client.ListSponsors(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list sponsors: %w", err)
+		}
+		r.sponsors = sponsors
+	}
+	return resolveRef("sponsor", ref, r.sponsors, func(s sdk.Sponsor) (string, string) { return s.ID, s.Name })
+}
+
+// resolveRef returns the ID of the item whose ID or, failing that, whose
+// name is ref.
+func resolveRef[T any](kind, ref string, items []T, key func(T) (id, name string)) (string, error) {
+	var matches []string
+	for _, item := range items {
+		id, name := key(item)
+		if id == ref {
+			return id, nil
+		}
+		if name == ref {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s with ID or name %q", kind, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss are named %q; use one of their IDs: %s", len(matches), kind, ref, strings.Join(matches, ", "))
+	}
+}
+
+// applyPlan is the work needed to bring one event in line with its manifest.
+type applyPlan struct {
+	live   *sdk.Event // nil when the event is created
+	create sdk.CreateEventRequest
+	edit   sdk.EditEventRequest
+	deploy bool // createEvent and link after the Convex write
+	result applyResult
+}
+
+// planManifest finds the live event for m and works out the changes.
+func planManifest(ctx context.Context, refs *refResolver, m eventManifest) (*applyPlan, error) {
+	loc := userZone()
+	if m.Timezone != "" {
+		zone, err := time.LoadLocation(m.Timezone)
+		if err != nil {
+			return nil, usageError{fmt.Errorf("invalid timezone %q: %w", m.Timezone, err)}
+		}
+		loc = zone
+	}
+	want, err := m.resolve(ctx, refs, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := findEvent(ctx, m, want.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	plan := &applyPlan{live: live, result: applyResult{Source: m.source, Name: m.Name}}
+	if live == nil {
+		return plan, plan.planCreate(m, want)
+	}
+	plan.planUpdate(m, want)
+	return plan, nil
+}
+
+// desiredEvent is a manifest with references resolved to IDs and times
+// parsed. Unmanaged fields keep their nil or zero value.
+type desiredEvent struct {
+	sdk.EditEventRequest
+	TeamID   string
+	Duration time.Duration
+}
+
+func (m eventManifest) resolve(ctx context.Context, refs *refResolver, loc *time.Location) (*desiredEvent, error) {
+	want := &desiredEvent{EditEventRequest: sdk.EditEventRequest{
+		Name:        &m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		MaxTickets:  m.MaxTickets,
+		Location:    m.Location,
+	}}
+	if m.Price != nil && *m.Price < 0 {
+		return nil, usageError{fmt.Errorf("price must not be negative")}
+	}
+	if m.MaxTickets != nil && *m.MaxTickets <= 0 {
+		return nil, usageError{fmt.Errorf("maxTickets must be positive")}
+	}
+	for _, t := range []struct {
+		value string
+		field **int64
+	}{{m.Start, &want.StartTime}, {m.End, &want.EndTime}} {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := parseTime(t.value, loc)
+		if err != nil {
+			return nil, usageError{err}
+		}
+		ms := parsed.UnixMilli()
+		*t.field = &ms
+	}
+	if m.Duration != "" {
+		if m.End != "" {
+			return nil, usageError{fmt.Errorf("set end or duration, not both")}
+		}
+		duration, err := time.ParseDuration(m.Duration)
+		if err != nil || duration <= 0 {
+			return nil, usageError{fmt.Errorf("invalid duration %q: want a positive length such as 3h or 90m", m.Duration)}
+		}
+		want.Duration = duration
+	}
+
+	var err error
+	if m.Team != "" {
+		if want.TeamID, err = refs.team(ctx, m.Team); err != nil {
+			return nil, err
+		}
+		want.EditEventRequest.TeamID = &want.TeamID
+	}
+	if m.Project != nil {
+		if *m.Project == "" {
+			return nil, usageError{fmt.Errorf("project cannot be empty; leave it out to keep the current one")}
+		}
+		projectID, err := refs.project(ctx, *m.Project)
+		if err != nil {
+			return nil, err
+		}
+		want.ProjectID = &projectID
+	}
+	if m.Sponsors != nil {
+		sponsors := []string{}
+		for _, ref := range *m.Sponsors {
+			id, err := refs.sponsor(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(sponsors, id) {
+				sponsors = append(sponsors, id)
+			}
+		}
+		want.Sponsors = &sponsors
+	}
+	return want, nil
+}
+
+// findEvent returns the event m describes: the one with its id, or else the
+// one not cancelled with its name in teamID. It returns nil if there is none.
+func findEvent(ctx context.Context, m eventManifest, teamID string) (*sdk.Event, error) {
+	if m.ID != "" {
+		detail, err := apiClient().GetEvent(ctx, m.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event %s: %w", m.ID, err)
+		}
+		return &detail.Event, nil
+	}
+	if teamID == "" {
+		return nil, usageError{fmt.Errorf("set id or team so the event can be found")}
+	}
+	events, err := apiClient().ListEvents(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	var matches []sdk.Event
+	for _, event := range events {
+		if event.Name == m.Name && event.TeamID == teamID && event.Status != sdk.EventCancelled {
+			matches = append(matches, event)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, event := range matches {
+			ids[i] = event.ID
+		}
+		return nil, fmt.Errorf("%d events are named %q in this team; set id to one of: %s", len(matches), m.Name, strings.Join(ids, ", "))
+	}
+}
+
+func (p *applyPlan) planCreate(m eventManifest, want *desiredEvent) error {
+	if want.TeamID == "" {
+		return usageError{fmt.Errorf("team is required to create an event")}
+	}
+	if want.StartTime == nil {
+		return usageError{fmt.Errorf("start is required to create an event")}
+	}
+	start := *want.StartTime
+	if start < time.Now().UnixMilli() {
+		return usageError{fmt.Errorf("start %s is in the past", showTime(sdk.Millis(start)))}
+	}
+	var end int64
+	switch {
+	case want.EndTime != nil:
+		end = *want.EndTime
+	case want.Duration > 0:
+		end = start + want.Duration.Milliseconds()
+	default:
+		return usageError{fmt.Errorf("end or duration is required to create an event")}
+	}
+	if end <= start {
+		return usageError{fmt.Errorf("event ends (%s) before it starts (%s)", showTime(sdk.Millis(end)), showTime(sdk.Millis(start)))}
+	}
+
+	p.create = sdk.CreateEventRequest{
+		Name:           m.Name,
+		StartTime:      start,
+		EndTime:        end,
+		MaxTickets:     100,
+		TeamID:         want.TeamID,
+		CreatorAddress: walletAddress(),
+	}
+	if want.Description != nil {
+		p.create.Description = *want.Description
+	}
+	if want.Price != nil {
+		p.create.Price = *want.Price
+	}
+	if want.MaxTickets != nil {
+		p.create.MaxTickets = *want.MaxTickets
+	}
+	if want.Location != nil {
+		p.create.Location = *want.Location
+	}
+	if want.ProjectID != nil {
+		p.create.ProjectID = *want.ProjectID
+	}
+	if want.Sponsors != nil {
+		p.create.Sponsors = *want.Sponsors
+	}
+
+	p.result.Action = "create"
+	p.result.Changes = []fieldChange{
+		{Field: "startTime", To: showTime(sdk.Millis(start))},
+		{Field: "endTime", To: showTime(sdk.Millis(end))},
+		{Field: "price", To: fmt.Sprint(p.create.Price)},
+		{Field: "maxTickets", To: fmt.Sprint(p.create.MaxTickets)},
+	}
+	if m.OnChain {
+		p.deploy = true
+		p.result.OnChain = &onChainApply{Action: "deploy"}
+	}
+	return nil
+}
+
+func (p *applyPlan) planUpdate(m eventManifest, want *desiredEvent) {
+	live := p.live
+	p.result.EventID = live.ID
+	var changes []fieldChange
+	changed := func(field, from, to string) {
+		changes = append(changes, fieldChange{Field: field, From: from, To: to})
+	}
+
+	if *want.Name != live.Name {
+		p.edit.Name = want.Name
+		changed("name", live.Name, *want.Name)
+	}
+	if want.Description != nil && *want.Description != live.Description {
+		p.edit.Description = want.Description
+		changed("description", live.Description, *want.Description)
+	}
+	if want.StartTime != nil && *want.StartTime != int64(live.StartTime) {
+		p.edit.StartTime = want.StartTime
+		changed("startTime", showTime(live.StartTime), showTime(sdk.Millis(*want.StartTime)))
+	}
+	end := want.EndTime
+	if want.Duration > 0 {
+		start := int64(live.StartTime)
+		if want.StartTime != nil {
+			start = *want.StartTime
+		}
+		ms := start + want.Duration.Milliseconds()
+		end = &ms
+	}
+	if end != nil && *end != int64(live.EndTime) {
+		p.edit.EndTime = end
+		changed("endTime", showTime(live.EndTime), showTime(sdk.Millis(*end)))
+	}
+	if want.Price != nil && *want.Price != live.Price {
+		p.edit.Price = want.Price
+		changed("price", fmt.Sprint(live.Price), fmt.Sprint(*want.Price))
+	}
+	if want.MaxTickets != nil && *want.MaxTickets != live.MaxTickets {
+		p.edit.MaxTickets = want.MaxTickets
+		changed("maxTickets", fmt.Sprint(live.MaxTickets), fmt.Sprint(*want.MaxTickets))
+	}
+	if want.Location != nil && *want.Location != live.Location {
+		p.edit.Location = want.Location
+		changed("location", live.Location, *want.Location)
+	}
+	if want.EditEventRequest.TeamID != nil && want.TeamID != live.TeamID {
+		p.edit.TeamID = want.EditEventRequest.TeamID
+		changed("teamId", live.TeamID, want.TeamID)
+	}
+	if want.ProjectID != nil && *want.ProjectID != live.ProjectID {
+		p.edit.ProjectID = want.ProjectID
+		changed("projectId", live.ProjectID, *want.ProjectID)
+	}
+	if want.Sponsors != nil && !sameIDs(*want.Sponsors, live.Sponsors) {
+		p.edit.Sponsors = want.Sponsors
+		changed("sponsors", strings.Join(live.Sponsors, ", "), strings.Join(*want.Sponsors, ", "))
+	}
+
+	p.result.Changes = changes
+	p.result.Action = "unchanged"
+	if len(changes) > 0 {
+		p.result.Action = "update"
+	}
+
+	linked := live.OnChainEventID != nil && live.ContractAddress != ""
+	switch {
+	case linked && (p.edit.Name != nil || p.edit.Price != nil):
+		p.result.OnChain = &onChainApply{Action: "edit", EventID: fmt.Sprint(*live.OnChainEventID)}
+	case !linked && m.OnChain:
+		p.deploy = true
+		p.result.OnChain = &onChainApply{Action: "deploy"}
+	}
+	if linked && p.edit.MaxTickets != nil {
+		progress("warning: %s: maxTickets cannot change on-chain; the contract keeps its original capacity", m.source)
+	}
+}
+
+// apply carries out the plan: the contract edit before Convex, so that a
+// rejected transaction leaves both untouched, and a deploy after Convex, so
+// that the event exists to be linked.
+func (p *applyPlan) apply(ctx context.Context) error {
+	client := apiClient()
+	if p.live == nil {
+		progress("Creating event %q...", p.create.Name)
+		eventID, err := client.CreateEvent(ctx, p.create)
+		if err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
+		}
+		p.result.EventID = eventID
+	} else if p.result.Action == "update" {
+		if p.result.OnChain != nil && p.result.OnChain.Action == "edit" {
+			updated, receipt, err := editOnChain(ctx, *p.live, p.edit)
+			if err != nil {
+				return err
+			}
+			p.result.OnChain.TxHash = receipt.TxHash.Hex()
+			p.result.OnChain.Block = receipt.BlockNumber.Uint64()
+			p.result.OnChain.EventID = updated.EventId.String()
+		}
+		progress("Updating event %s...", p.live.ID)
+		if err := client.EditEvent(ctx, p.live.ID, p.edit); err != nil {
+			if p.result.OnChain != nil && p.result.OnChain.TxHash != "" {
+				return fmt.Errorf("event edited on-chain (tx %s) but not in Convex: %w", p.result.OnChain.TxHash, err)
+			}
+			return fmt.Errorf("failed to edit event: %w", err)
+		}
+	}
+
+	if p.deploy {
+		return p.deployAndLink(ctx)
+	}
+	return nil
+}
+
+// deployAndLink sends createEvent with the applied name, price and capacity
+// and records the new on-chain ID against the Convex event.
+func (p *applyPlan) deployAndLink(ctx context.Context) error {
+	name, price, maxTickets := p.create.Name, p.create.Price, p.create.MaxTickets
+	if p.live != nil {
+		name, price, maxTickets = p.live.Name, p.live.Price, p.live.MaxTickets
+		if p.edit.Name != nil {
+			name = *p.edit.Name
+		}
+		if p.edit.Price != nil {
+			price = *p.edit.Price
+		}
+		if p.edit.MaxTickets != nil {
+			maxTickets = *p.edit.MaxTickets
+		}
+	}
+
+	// The event is already saved, so every failure from here leaves it
+	// unlinked; say so, and that applying again retries the deploy.
+	notDeployed := func(err error) error {
+		return fmt.Errorf("event %s saved but not deployed; apply again to retry: %w", p.result.EventID, err)
+	}
+	key, err := signingKey()
+	if err != nil {
+		return notDeployed(err)
+	}
+	chainClient, err := dialChain(ctx)
+	if err != nil {
+		return notDeployed(err)
+	}
+	defer chainClient.Close()
+	market, err := marketFor(chainClient)
+	if err != nil {
+		return notDeployed(err)
+	}
+	opts, err := txOptions()
+	if err != nil {
+		return notDeployed(err)
+	}
+
+	created, receipt, err := market.CreateEvent(ctx, key, name, usdcUnits(price), big.NewInt(int64(maxTickets)), opts...)
+	if err != nil {
+		return notDeployed(err)
+	}
+	p.result.OnChain.EventID = created.EventId.String()
+	p.result.OnChain.TxHash = receipt.TxHash.Hex()
+	p.result.OnChain.Block = receipt.BlockNumber.Uint64()
+
+	// Applying again would deploy a second on-chain event, so retry the link
+	// here and, if it still fails, say how to link the one just created.
+	contract := market.Contract().Address().Hex()
+	if err := linkWithRetry(ctx, p.result.EventID, created.EventId.Int64(), contract); err != nil {
+		return fmt.Errorf("event deployed as on-chain #%s (tx %s) but not linked: %w\n"+
+			"Do not apply again, which would deploy it twice; link it with:\n  buddyevents events link %s --onchain-id %s --contract %s",
+			created.EventId, receipt.TxHash.Hex(), err, p.result.EventID, created.EventId, contract)
+	}
+	return nil
+}
+
+// linkAttempts is how many times deployAndLink tries LinkOnChain.
+const linkAttempts = 3
+
+// linkWithRetry calls LinkOnChain until it succeeds, fails for a reason
+// retrying cannot fix (a 4xx), or linkAttempts run out. Linking the same
+// IDs twice is harmless.
+func linkWithRetry(ctx context.Context, eventID string, onChainEventID int64, contract string) error {
+	var err error
+	for attempt := 1; attempt <= linkAttempts; attempt++ {
+		if err = apiClient().LinkOnChain(ctx, eventID, onChainEventID, contract); err == nil {
+			return nil
+		}
+		var apiErr *sdk.Error
+		if errors.As(err, &apiErr) && apiErr.Status < 500 && apiErr.Status != http.StatusTooManyRequests {
+			return err
+		}
+		if attempt == linkAttempts {
+			break
+		}
+		progress("Linking failed (%v); retrying...", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * 2 * time.Second):
+		}
+	}
+	return err
+}
+
+// sameIDs reports whether a and b hold the same IDs in any order.
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func init() {
+	eventsApplyCmd.Flags().StringArrayP("file", "f", nil, "Manifest file or directory, or - for stdin (repeatable)")
+	eventsApplyCmd.Flags().Bool("dry-run", false, "Print the plan without changing anything")
+	_ = eventsApplyCmd.MarkFlagRequired("file")
+
+	eventsCmd.AddCommand(eventsApplyCmd)
+}
@@ -0,0 +1,157 @@
+// / cli/cmd/chain.go — Shared helpers for commands that talk to Monad
+// / Dial the RPC, resolve fees, and bind the BuddyEvents and USDC contracts.
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// dialChain connects to the configured RPC, bounding each request by
+// --timeout, and, when the config or profile pins chain_id, refuses to talk
+// to any other chain.
+func dialChain(ctx context.Context) (*chain.Client, error) {
+	client, err := chain.Dial(ctx, cfg.MonadRPC, chain.WithCallTimeout(requestTimeout()))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ChainID != 0 && client.ChainID().Uint64() != cfg.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("RPC %s serves chain %s, but chain_id is %d", cfg.MonadRPC, client.ChainID(), cfg.ChainID)
+	}
+	return client, nil
+}
+
+// marketFor binds the configured BuddyEvents deployment and USDC token.
+func marketFor(client *chain.Client) (*chain.Market, error) {
+	contract, err := chain.ParseAddress("contract", cfg.ContractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w (set contract_address in config)", err)
+	}
+	return marketAt(client, contract)
+}
+
+// marketAt binds the deployment at contract, such as the one an event is
+// linked to, with the configured USDC token.
+func marketAt(client *chain.Client, contract common.Address) (*chain.Market, error) {
+	usdc, err := chain.ParseAddress("USDC", cfg.USDCAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w (set usdc_address in config)", err)
+	}
+	return chain.NewMarket(client, contract, usdc), nil
+}
+
+func usdcContract(client *chain.Client) (*chain.ERC20, error) {
+	addr, err := chain.ParseAddress("USDC", cfg.USDCAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w (set usdc_address in config)", err)
+	}
+	return chain.NewERC20(addr, client.Backend()), nil
+}
+
+// parseUint256 parses a base-10 integer flag such as an event or token ID.
+func parseUint256(label, value string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s: %q", label, value)
+	}
+	return n, nil
+}
+
+// feeOptions reads the global --gas-limit, --max-fee and --priority-fee flags.
+func feeOptions() (chain.FeeOptions, error) {
+	flags := rootCmd.PersistentFlags()
+	gasLimit, _ := flags.GetUint64("gas-limit")
+	maxFee, _ := flags.GetString("max-fee")
+	priorityFee, _ := flags.GetString("priority-fee")
+
+	fees := chain.FeeOptions{GasLimit: gasLimit}
+	var err error
+	if fees.MaxFee, err = parseGwei("--max-fee", maxFee); err != nil {
+		return fees, err
+	}
+	if fees.PriorityFee, err = parseGwei("--priority-fee", priorityFee); err != nil {
+		return fees, err
+	}
+	return fees, nil
+}
+
+// receiptOptions reads the global --confirmations and --receipt-timeout flags.
+func receiptOptions() chain.ReceiptOptions {
+	confirmations, _ := rootCmd.PersistentFlags().GetUint64("confirmations")
+	timeout, _ := rootCmd.PersistentFlags().GetDuration("receipt-timeout")
+	return chain.ReceiptOptions{Confirmations: confirmations, Timeout: timeout}
+}
+
+// txOptions configures Market operations from the global flags and prints
+// each step.
+func txOptions(extra ...chain.TxOption) ([]chain.TxOption, error) {
+	fees, err := feeOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts := []chain.TxOption{
+		chain.WithFees(fees),
+		chain.WithReceiptOptions(receiptOptions()),
+		chain.WithLogger(progress),
+	}
+	return append(opts, extra...), nil
+}
+
+// transactor resolves gas and fees for the next transaction signed by key,
+// honoring the global --gas-limit, --max-fee and --priority-fee flags.
+func transactor(ctx context.Context, client *chain.Client, key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	fees, err := feeOptions()
+	if err != nil {
+		return nil, err
+	}
+	return client.Transactor(ctx, key, fees)
+}
+
+// parseGwei converts a decimal gwei amount to wei; empty means unset.
+func parseGwei(label, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	gwei, ok := new(big.Float).SetString(value)
+	if !ok || gwei.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s: %q (expected gwei, e.g. 52.5)", label, value)
+	}
+	wei, _ := new(big.Float).Mul(gwei, big.NewFloat(1e9)).Int(nil)
+	return wei, nil
+}
+
+// waitForReceipt waits for hash using the global --confirmations and
+// --receipt-timeout flags.
+func waitForReceipt(ctx context.Context, client *chain.Client, hash common.Hash) (*types.Receipt, error) {
+	opts := receiptOptions()
+	if opts.Confirmations > 1 {
+		progress("Waiting for %d confirmations...", opts.Confirmations)
+	}
+	return client.WaitForReceipt(ctx, hash, opts)
+}
+
+// formatMON renders wei as MON with 6 decimals.
+func formatMON(wei *big.Int) string {
+	mon := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18))
+	return mon.Text('f', 6)
+}
+
+// usdcUnits converts a Convex USDC price (e.g. 10.5) to 6-decimal units.
+func usdcUnits(price float64) *big.Int {
+	return big.NewInt(int64(math.Round(price * 1e6)))
+}
+
+// formatUSDC renders 6-decimal token units as a human-readable amount.
+func formatUSDC(units *big.Int) string {
+	return chain.FormatUnits(units, chain.USDCDecimals)
+}
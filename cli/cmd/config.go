@@ -0,0 +1,390 @@
+// / cli/cmd/config.go — Config file and profile management commands
+// / get/set/unset/show/validate keys; profiles switch API, RPC, chain and contracts.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration management (get, set, unset, show, validate, profiles)",
+}
+
+// ===== config get =====
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a config key",
+	Long: `Print the effective value of a config key. The table format prints the
+bare value, for use in scripts; the others also give its source.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reveal, _ := cmd.Flags().GetBool("reveal")
+		value, err := cfg.Display(args[0])
+		if reveal {
+			value, err = cfg.Get(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		if output.format == "table" {
+			fmt.Println(value)
+			return nil
+		}
+		return printResult(configEntry{Key: args[0], Value: value, Source: cfg.Source(args[0])})
+	},
+}
+
+// configEntry is one key of the effective config.
+type configEntry struct {
+	Key    string        `json:"key"`
+	Value  string        `json:"value"` // secrets redacted unless --reveal
+	Source config.Source `json:"source"`
+}
+
+// ===== config set =====
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Write a config key to the config file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+		if err := fileCfg.Set(key, value); err != nil {
+			return err
+		}
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		progress("%s set", key)
+		warnShadowed(key)
+		return nil
+	},
+}
+
+// ===== config unset =====
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Reset a config key in the config file to its default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if err := fileCfg.Unset(key); err != nil {
+			return err
+		}
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		progress("%s reset to default", key)
+		warnShadowed(key)
+		return nil
+	},
+}
+
+// warnShadowed notes when a file edit is hidden by a profile, env var or flag.
+func warnShadowed(key string) {
+	switch source := cfg.Source(key); source {
+	case config.SourceProfile:
+		fmt.Fprintf(os.Stderr, "note: profile %s overrides %s; edit it with: buddyevents config profiles create %s\n", cfg.Profile, key, cfg.Profile)
+	case config.SourceEnv, config.SourceFlag:
+		fmt.Fprintf(os.Stderr, "note: %s is currently overridden by %s\n", key, source)
+	}
+}
+
+// ===== config show =====
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show effective config values and where each came from (secrets redacted)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		progress("Config file: %s", config.Path(configPath))
+		if cfg.Profile != "" {
+			progress("Profile:     %s", cfg.Profile)
+		}
+
+		var entries []configEntry
+		for _, key := range config.Keys() {
+			value, err := cfg.Display(key)
+			if err != nil {
+				return err
+			}
+			if value == "" && output.format == "table" {
+				value = "-"
+			}
+			entries = append(entries, configEntry{Key: key, Value: value, Source: cfg.Source(key)})
+		}
+		if len(cfg.Accounts) > 0 {
+			entries = append(entries, configEntry{
+				Key:    "accounts",
+				Value:  fmt.Sprintf("%d derived", len(cfg.Accounts)),
+				Source: cfg.Source("accounts"),
+			})
+		}
+		return printResult(entries, "key", "value", "source")
+	},
+}
+
+// ===== config validate =====
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check addresses, RPC chain ID and contract code for the effective config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		var checks []configCheck
+		failures := 0
+		report := func(key string, err error, detail string) {
+			status := "ok"
+			if err != nil {
+				status, detail = "FAIL", err.Error()
+				failures++
+			}
+			checks = append(checks, configCheck{Status: status, Key: key, Detail: detail, Source: cfg.Source(key)})
+		}
+
+		for _, key := range config.Keys() {
+			value, _ := cfg.Get(key)
+			if value == "" || config.IsSecret(key) {
+				continue
+			}
+			if err := config.Validate(key, value); err != nil {
+				report(key, err, "")
+			}
+		}
+
+		client, err := chain.Dial(ctx, cfg.MonadRPC, chain.WithCallTimeout(requestTimeout()))
+		if err != nil {
+			report("monad_rpc", err, "")
+		} else {
+			defer client.Close()
+			served := client.ChainID().Uint64()
+			switch {
+			case cfg.ChainID == 0:
+				report("monad_rpc", nil, fmt.Sprintf("%s answers eth_chainId %d (chain_id not pinned)", cfg.MonadRPC, served))
+			case served != cfg.ChainID:
+				report("chain_id", fmt.Errorf("%s answers eth_chainId %d, want %d", cfg.MonadRPC, served, cfg.ChainID), "")
+			default:
+				report("chain_id", nil, fmt.Sprintf("%s answers eth_chainId %d", cfg.MonadRPC, served))
+			}
+
+			for _, key := range []string{"contract_address", "usdc_address"} {
+				value, _ := cfg.Get(key)
+				if value == "" {
+					report(key, fmt.Errorf("not set"), "")
+					continue
+				}
+				if config.Validate(key, value) != nil {
+					continue // already reported
+				}
+				code, err := client.Backend().CodeAt(ctx, common.HexToAddress(value), nil)
+				switch {
+				case err != nil:
+					report(key, fmt.Errorf("eth_getCode failed: %w", err), "")
+				case len(code) == 0:
+					report(key, fmt.Errorf("no contract code at %s", value), "")
+				default:
+					report(key, nil, fmt.Sprintf("%s has %d bytes of code", value, len(code)))
+				}
+			}
+		}
+
+		for _, key := range []string{"keystore", "seed", "passphrase_file"} {
+			value, _ := cfg.Get(key)
+			if value == "" {
+				continue
+			}
+			if _, err := os.Stat(value); err != nil {
+				report(key, err, "")
+			} else {
+				report(key, nil, value)
+			}
+		}
+
+		if err := printResult(checks, "status", "key", "detail", "source"); err != nil {
+			return err
+		}
+		if failures > 0 {
+			return fmt.Errorf("config validation failed: %d problem(s)", failures)
+		}
+		return nil
+	},
+}
+
+// configCheck is one result of config validate.
+type configCheck struct {
+	Status string        `json:"status"` // ok or FAIL
+	Key    string        `json:"key"`
+	Detail string        `json:"detail"`
+	Source config.Source `json:"source"`
+}
+
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage named profiles (list, use, create)",
+}
+
+// ===== config profiles list =====
+var configProfilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles and which one is in effect",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := fileCfg.ProfileNames()
+		if len(names) == 0 {
+			progress("No profiles configured. Create one with:")
+			progress("  buddyevents config profiles create <name> --from %s", strings.Join(config.PresetNames(), "|"))
+		}
+
+		profiles := make([]profileEntry, 0, len(names))
+		for _, name := range names {
+			effective, err := fileCfg.WithProfile(name)
+			if err != nil {
+				return err
+			}
+			profiles = append(profiles, profileEntry{
+				Active:  name == cfg.Profile,
+				Name:    name,
+				ChainID: effective.ChainID,
+				RPC:     effective.MonadRPC,
+				API:     effective.APIURL,
+			})
+		}
+		return printResult(profiles, "active", "name", "chainId", "rpc", "api")
+	},
+}
+
+// profileEntry is one profile as config profiles list shows it.
+type profileEntry struct {
+	Active  bool   `json:"active"` // the profile in effect
+	Name    string `json:"name"`
+	ChainID uint64 `json:"chainId,omitempty"`
+	RPC     string `json:"rpc"`
+	API     string `json:"api"`
+}
+
+// ===== config profiles use =====
+var configProfilesUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the default (pass \"\" to go back to top-level settings)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if name != "" {
+			if _, ok := fileCfg.Profiles[name]; !ok {
+				return fmt.Errorf("unknown profile %q. Run: buddyevents config profiles list", name)
+			}
+		}
+
+		fileCfg.Profile = name
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if name == "" {
+			progress("Using top-level settings (no profile)")
+		} else {
+			progress("Using profile %s", name)
+		}
+		return nil
+	},
+}
+
+// ===== config profiles create =====
+var configProfilesCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create or update a profile, optionally from a preset (local, testnet, mainnet)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		from, _ := cmd.Flags().GetString("from")
+
+		profile, exists := fileCfg.Profiles[name]
+		if from != "" {
+			preset, ok := config.Preset(from)
+			if !ok {
+				return fmt.Errorf("unknown preset %q (want one of: %s)", from, strings.Join(config.PresetNames(), ", "))
+			}
+			profile = preset
+		}
+
+		flags := cmd.Flags()
+		setString := func(flag, key string, dst *string) error {
+			if !flags.Changed(flag) {
+				return nil
+			}
+			value, _ := flags.GetString(flag)
+			if err := config.Validate(key, value); err != nil {
+				return usageError{fmt.Errorf("--%s: %w", flag, err)}
+			}
+			*dst = value
+			return nil
+		}
+		for _, f := range []struct {
+			flag, key string
+			dst       *string
+		}{
+			{"api", "api_url", &profile.APIURL},
+			{"convex", "convex_url", &profile.ConvexURL},
+			{"rpc", "monad_rpc", &profile.MonadRPC},
+			{"contract", "contract_address", &profile.ContractAddress},
+			{"usdc", "usdc_address", &profile.USDCAddress},
+			{"profile-account", "account", &profile.Account},
+		} {
+			if err := setString(f.flag, f.key, f.dst); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("chain-id") {
+			profile.ChainID, _ = flags.GetUint64("chain-id")
+		}
+
+		if fileCfg.Profiles == nil {
+			fileCfg.Profiles = map[string]config.Profile{}
+		}
+		fileCfg.Profiles[name] = profile
+		if use, _ := flags.GetBool("use"); use {
+			fileCfg.Profile = name
+		}
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		if exists {
+			progress("Profile %s updated", name)
+		} else {
+			progress("Profile %s created", name)
+		}
+		if fileCfg.Profile != name {
+			progress("Activate with: buddyevents config profiles use %s (or --profile %s)", name, name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configGetCmd.Flags().Bool("reveal", false, "print secret values instead of redacting them")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	configProfilesCmd.AddCommand(configProfilesListCmd)
+	configProfilesCmd.AddCommand(configProfilesUseCmd)
+
+	configProfilesCreateCmd.Flags().String("from", "", "start from a built-in preset: "+strings.Join(config.PresetNames(), ", "))
+	configProfilesCreateCmd.Flags().String("api", "", "API base URL")
+	configProfilesCreateCmd.Flags().String("convex", "", "Convex deployment URL")
+	configProfilesCreateCmd.Flags().String("rpc", "", "Monad RPC URL")
+	configProfilesCreateCmd.Flags().Uint64("chain-id", 0, "expected chain ID (0 skips the check)")
+	configProfilesCreateCmd.Flags().String("contract", "", "BuddyEvents contract address")
+	configProfilesCreateCmd.Flags().String("usdc", "", "USDC token address")
+	configProfilesCreateCmd.Flags().String("profile-account", "", "HD account index or address this profile acts as")
+	configProfilesCreateCmd.Flags().Bool("use", false, "make the profile the default")
+	configProfilesCmd.AddCommand(configProfilesCreateCmd)
+
+	configCmd.AddCommand(configProfilesCmd)
+}
@@ -1,40 +1,202 @@
 /// cli/cmd/events.go — Event management commands
-/// list, create, edit, cancel events via API
+/// list, get, create, edit, cancel events via API
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"math/big"
+	"time"
 
-	"buddyevents/internal/api"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
 var eventsCmd = &cobra.Command{
 	Use:   "events",
-	Short: "Manage events (list, create, edit, cancel)",
+	Short: "Manage events (list, get, watch, create, edit, apply, import, export, cancel)",
 }
 
 // ===== events list =====
 var eventsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available events",
+	Long: `List events, filtered and sorted by the API. With --limit, one page is
+listed and the --cursor for the next page is printed to stderr.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		status, _ := cmd.Flags().GetString("status")
+		flags := cmd.Flags()
+		sort, _ := flags.GetString("sort")
+		order, _ := flags.GetString("order")
+		limit, _ := flags.GetInt("limit")
+		cursor, _ := flags.GetString("cursor")
 
-		client := api.NewClient(cfg.APIURL)
-		events, err := client.ListEvents(status)
+		page := sdk.EventQuery{Sort: sdk.EventSort(sort), Order: sdk.SortOrder(order), Limit: limit, Cursor: cursor}
+		switch page.Sort {
+		case sdk.SortCreated, sdk.SortStart, sdk.SortPrice, sdk.SortPopularity:
+		default:
+			return usageError{fmt.Errorf("unknown --sort %q (want created, start, price or popularity)", sort)}
+		}
+		switch page.Order {
+		case "", sdk.SortAsc, sdk.SortDesc:
+		default:
+			return usageError{fmt.Errorf("unknown --order %q (want asc or desc)", order)}
+		}
+		if limit < 0 {
+			return usageError{fmt.Errorf("--limit must not be negative")}
+		}
+
+		ctx := cmd.Context()
+		filter, err := eventFilterFlags(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		result, err := filter.search(ctx, apiClient(), page)
 		if err != nil {
-			return fmt.Errorf("failed to list events: %w", err)
+			return err
 		}
 
-		out, _ := json.MarshalIndent(events, "", "  ")
-		fmt.Println(string(out))
+		if err := printResult(result.Events, eventColumns...); err != nil {
+			return err
+		}
+		if result.NextCursor != "" {
+			progress("More events: --cursor %s", result.NextCursor)
+		}
 		return nil
 	},
 }
 
+// eventColumns are the table columns for events.
+var eventColumns = []string{"_id", "name", "status", "startTime", "location", "price", "ticketsSold", "maxTickets"}
+
+// ===== events get =====
+var eventsGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Show one event in full, cross-checked against the contract",
+	Long: `Show an event with its team, project, sponsors and moderation state.
+When the event is linked to a contract (onChainEventId and contractAddress),
+getEvent is called on-chain and any price, tickets-sold or active-state
+mismatch with Convex is reported in "mismatches" and as a warning.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		detail, err := apiClient().GetEvent(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get event: %w", err)
+		}
+
+		result := eventDetail{
+			Event:       detail.Event,
+			TeamName:    detail.TeamName,
+			ProjectName: detail.ProjectName,
+			Sponsors:    make([]sponsorInfo, len(detail.Sponsors)),
+			TicketsLeft: detail.Event.TicketsLeft(),
+			Mismatches:  []string{},
+		}
+		for i, sponsor := range detail.Sponsors {
+			result.Sponsors[i] = sponsorInfo(sponsor)
+		}
+
+		skipChain, _ := cmd.Flags().GetBool("skip-chain")
+		if !skipChain && detail.Event.OnChainEventID != nil && detail.Event.ContractAddress != "" {
+			// The Convex record is still worth showing when the RPC is down.
+			onChain, mismatches, err := crossCheckEvent(ctx, detail.Event)
+			if err != nil {
+				progress("warning: on-chain check skipped: %v", err)
+			} else {
+				result.OnChain, result.Mismatches = onChain, mismatches
+			}
+			for _, m := range result.Mismatches {
+				progress("warning: %s", m)
+			}
+		}
+		return printResult(result, eventDetailColumns...)
+	},
+}
+
+// eventDetail is the result of events get.
+type eventDetail struct {
+	sdk.Event
+	TeamName    string        `json:"teamName"`
+	ProjectName string        `json:"projectName"`
+	Sponsors    []sponsorInfo `json:"sponsors"` // resolved; shadows the IDs in Event
+	TicketsLeft int           `json:"ticketsLeft"`
+	OnChain     *onChainEvent `json:"onChain"` // null when not checked
+	Mismatches  []string      `json:"mismatches"`
+}
+
+var eventDetailColumns = []string{
+	"_id", "name", "description", "status", "moderationStatus", "moderationNotes", "submissionSource",
+	"startTime", "endTime", "location", "price", "ticketsSold", "maxTickets", "ticketsLeft",
+	"teamName", "projectName", "sponsors", "creatorAddress", "onChainEventId", "contractAddress",
+	"onChain.price", "onChain.ticketsSold", "onChain.active", "mismatches",
+}
+
+// sponsorInfo shows a sponsor by name in tables.
+type sponsorInfo sdk.Sponsor
+
+func (s sponsorInfo) String() string {
+	return s.Name
+}
+
+// onChainEvent is the contract's view of an event, from getEvent.
+type onChainEvent struct {
+	Name        string `json:"name"`
+	Price       string `json:"price"` // USDC
+	TicketsSold uint64 `json:"ticketsSold"`
+	MaxTickets  uint64 `json:"maxTickets"`
+	Organizer   string `json:"organizer"`
+	Active      bool   `json:"active"`
+}
+
+// crossCheckEvent reads event from its contract and describes every price,
+// tickets-sold and active-state difference from the Convex record.
+func crossCheckEvent(ctx context.Context, event sdk.Event) (*onChainEvent, []string, error) {
+	contract, err := chain.ParseAddress("contract", event.ContractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := dialChain(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	id := big.NewInt(*event.OnChainEventID)
+	evt, err := chain.NewBuddyEvents(contract, client.Backend()).GetEvent(&bind.CallOpts{Context: ctx}, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getEvent(%s) failed: %w", id, err)
+	}
+	onChain := &onChainEvent{
+		Name:        evt.Name,
+		Price:       formatUSDC(evt.PriceInUSDC),
+		TicketsSold: evt.TicketsSold.Uint64(),
+		MaxTickets:  evt.MaxTickets.Uint64(),
+		Organizer:   evt.Organizer.Hex(),
+		Active:      evt.Active,
+	}
+	if evt.Organizer == (common.Address{}) {
+		return onChain, []string{fmt.Sprintf("event #%s does not exist on contract %s", id, contract.Hex())}, nil
+	}
+
+	mismatches := []string{}
+	price := usdcUnits(event.Price)
+	if price.Cmp(evt.PriceInUSDC) != 0 {
+		mismatches = append(mismatches, fmt.Sprintf("price: Convex %s USDC, on-chain %s USDC", formatUSDC(price), onChain.Price))
+	}
+	if uint64(event.TicketsSold) != onChain.TicketsSold {
+		mismatches = append(mismatches, fmt.Sprintf("tickets sold: Convex %d, on-chain %d", event.TicketsSold, onChain.TicketsSold))
+	}
+	if active := event.Status == sdk.EventActive; active != evt.Active {
+		mismatches = append(mismatches, fmt.Sprintf("active: Convex status is %s, on-chain active is %t", event.Status, evt.Active))
+	}
+	return onChain, mismatches, nil
+}
+
 // ===== events create =====
 var eventsCreateCmd = &cobra.Command{
 	Use:   "create",
@@ -42,8 +204,6 @@ var eventsCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
 		desc, _ := cmd.Flags().GetString("description")
-		start, _ := cmd.Flags().GetInt64("start")
-		end, _ := cmd.Flags().GetInt64("end")
 		price, _ := cmd.Flags().GetFloat64("price")
 		maxTickets, _ := cmd.Flags().GetInt("max-tickets")
 		teamID, _ := cmd.Flags().GetString("team-id")
@@ -51,15 +211,22 @@ var eventsCreateCmd = &cobra.Command{
 		creator, _ := cmd.Flags().GetString("creator")
 
 		if creator == "" {
-			creator = cfg.WalletAddress
+			creator = walletAddress()
 		}
 
-		client := api.NewClient(cfg.APIURL)
-		eventID, err := client.CreateEvent(api.CreateEventRequest{
+		start, end, err := eventWindow(cmd)
+		if err != nil {
+			return err
+		}
+		if start.Before(time.Now()) {
+			return usageError{fmt.Errorf("--start %s is in the past", start.In(userZone()).Format(time.RFC3339))}
+		}
+
+		eventID, err := apiClient().CreateEvent(cmd.Context(), sdk.CreateEventRequest{
 			Name:           name,
 			Description:    desc,
-			StartTime:      start,
-			EndTime:        end,
+			StartTime:      start.UnixMilli(),
+			EndTime:        end.UnixMilli(),
 			Price:          price,
 			MaxTickets:     maxTickets,
 			TeamID:         teamID,
@@ -70,11 +237,235 @@ var eventsCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create event: %w", err)
 		}
 
-		fmt.Printf("Event created: %s\n", eventID)
-		return nil
+		return printResult(eventChange{EventID: eventID, Status: "created"}, "eventId", "status")
 	},
 }
 
+// eventWindow reads --start with --end or --duration, in the --timezone
+// zone unless the values carry their own, and checks end is after start.
+func eventWindow(cmd *cobra.Command) (time.Time, time.Time, error) {
+	loc := userZone()
+	start, err := timeFlag(cmd, "start", loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	var end time.Time
+	switch {
+	case cmd.Flags().Changed("duration"):
+		duration, _ := cmd.Flags().GetDuration("duration")
+		end = start.Add(duration)
+	case cmd.Flags().Changed("end"):
+		if end, err = timeFlag(cmd, "end", loc); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	default:
+		return time.Time{}, time.Time{}, usageError{fmt.Errorf("pass --end or --duration")}
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, usageError{fmt.Errorf("event ends (%s) before it starts (%s)",
+			end.In(loc).Format(time.RFC3339), start.In(loc).Format(time.RFC3339))}
+	}
+	return start, end, nil
+}
+
+// ===== events edit =====
+var eventsEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edit an event, keeping the contract in sync",
+	Long: `Change the given fields of an event; fields without a flag are kept.
+When the event is linked on-chain and --name or --price changes, editEvent
+is sent first with the configured wallet, which must be the organizer (the
+contract locks the price after the first sale). Convex is updated after the
+transaction is confirmed. The contract's capacity is fixed, so --max-tickets
+on a linked event is refused unless --skip-chain is passed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		flags := cmd.Flags()
+
+		var req sdk.EditEventRequest
+		var fields []string
+		if flags.Changed("name") {
+			name, _ := flags.GetString("name")
+			if name == "" {
+				return usageError{fmt.Errorf("--name cannot be empty")}
+			}
+			req.Name = &name
+			fields = append(fields, "name")
+		}
+		if flags.Changed("description") {
+			desc, _ := flags.GetString("description")
+			req.Description = &desc
+			fields = append(fields, "description")
+		}
+		if flags.Changed("start") {
+			start, err := timeFlag(cmd, "start", userZone())
+			if err != nil {
+				return err
+			}
+			ms := start.UnixMilli()
+			req.StartTime = &ms
+			fields = append(fields, "startTime")
+		}
+		if flags.Changed("end") {
+			end, err := timeFlag(cmd, "end", userZone())
+			if err != nil {
+				return err
+			}
+			ms := end.UnixMilli()
+			req.EndTime = &ms
+			fields = append(fields, "endTime")
+		}
+		if flags.Changed("price") {
+			price, _ := flags.GetFloat64("price")
+			if price < 0 {
+				return usageError{fmt.Errorf("--price must not be negative")}
+			}
+			req.Price = &price
+			fields = append(fields, "price")
+		}
+		if flags.Changed("max-tickets") {
+			maxTickets, _ := flags.GetInt("max-tickets")
+			req.MaxTickets = &maxTickets
+			fields = append(fields, "maxTickets")
+		}
+		if flags.Changed("location") {
+			location, _ := flags.GetString("location")
+			req.Location = &location
+			fields = append(fields, "location")
+		}
+		if len(fields) == 0 {
+			return usageError{fmt.Errorf("nothing to edit; pass at least one of --name, --description, --start, --end, --price, --max-tickets, --location")}
+		}
+
+		ctx := cmd.Context()
+		result := eventEdit{EventID: id, Fields: fields}
+		skipChain, _ := flags.GetBool("skip-chain")
+		if !skipChain && (req.Name != nil || req.Price != nil || req.MaxTickets != nil) {
+			detail, err := apiClient().GetEvent(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get event: %w", err)
+			}
+			event := detail.Event
+			linked := event.OnChainEventID != nil && event.ContractAddress != ""
+			if linked && req.MaxTickets != nil {
+				return usageError{fmt.Errorf("event %s is linked on-chain, where maxTickets cannot change; pass --skip-chain to change it in Convex only", id)}
+			}
+			if linked && (req.Name != nil || req.Price != nil) {
+				updated, receipt, err := editOnChain(ctx, event, req)
+				if err != nil {
+					return err
+				}
+				result.OnChain = &onChainEdit{
+					EventID: updated.EventId.String(),
+					Name:    updated.Name,
+					Price:   formatUSDC(updated.Price),
+					TxHash:  receipt.TxHash.Hex(),
+					Block:   receipt.BlockNumber.Uint64(),
+				}
+			}
+		}
+
+		if err := apiClient().EditEvent(ctx, id, req); err != nil {
+			if result.OnChain != nil {
+				return fmt.Errorf("event edited on-chain (tx %s) but not in Convex: %w\nRetry with --skip-chain", result.OnChain.TxHash, err)
+			}
+			return fmt.Errorf("failed to edit event: %w", err)
+		}
+		return printResult(result, "eventId", "fields", "onChain.eventId", "onChain.name", "onChain.price", "onChain.txHash", "onChain.block")
+	},
+}
+
+// eventEdit is the result of events edit.
+type eventEdit struct {
+	EventID string       `json:"eventId"`
+	Fields  []string     `json:"fields"`  // JSON names of the changed fields
+	OnChain *onChainEdit `json:"onChain"` // null when the contract was not touched
+}
+
+// onChainEdit is the decoded EventUpdated log of an editEvent transaction.
+type onChainEdit struct {
+	EventID string `json:"eventId"`
+	Name    string `json:"name"`
+	Price   string `json:"price"` // USDC
+	TxHash  string `json:"txHash"`
+	Block   uint64 `json:"block"`
+}
+
+// editOnChain sends editEvent for the name and price in req to the contract
+// event is linked to.
+func editOnChain(ctx context.Context, event sdk.Event, req sdk.EditEventRequest) (*chain.EventUpdated, *types.Receipt, error) {
+	contract, err := chain.ParseAddress("contract", event.ContractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := signingKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := dialChain(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	market, err := marketAt(client, contract)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts, err := txOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var name string
+	var price *big.Int
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.Price != nil {
+		price = usdcUnits(*req.Price)
+	}
+	return market.EditEvent(ctx, key, big.NewInt(*event.OnChainEventID), name, price, opts...)
+}
+
+// ===== events link =====
+var eventsLinkCmd = &cobra.Command{
+	Use:   "link <id>",
+	Short: "Record the on-chain event an event was deployed as",
+	Long: `Link an event to an on-chain event that already exists, for example one
+that events apply deployed but could not link. Nothing is sent to the chain.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		onChainID, _ := cmd.Flags().GetInt64("onchain-id")
+		if onChainID < 0 {
+			return usageError{fmt.Errorf("--onchain-id must not be negative")}
+		}
+		contractFlag, _ := cmd.Flags().GetString("contract")
+		if contractFlag == "" {
+			contractFlag = cfg.ContractAddress
+		}
+		contract, err := chain.ParseAddress("contract", contractFlag)
+		if err != nil {
+			return usageError{fmt.Errorf("%w (pass --contract or set contract_address in config)", err)}
+		}
+
+		if err := apiClient().LinkOnChain(cmd.Context(), args[0], onChainID, contract.Hex()); err != nil {
+			return fmt.Errorf("failed to link event: %w", err)
+		}
+		return printResult(eventLink{EventID: args[0], OnChainEventID: onChainID, ContractAddress: contract.Hex()},
+			"eventId", "onChainEventId", "contractAddress")
+	},
+}
+
+// eventLink is the result of events link.
+type eventLink struct {
+	EventID         string `json:"eventId"`
+	OnChainEventID  int64  `json:"onChainEventId"`
+	ContractAddress string `json:"contractAddress"`
+}
+
 // ===== events cancel =====
 var eventsCancelCmd = &cobra.Command{
 	Use:   "cancel",
@@ -85,26 +476,38 @@ var eventsCancelCmd = &cobra.Command{
 			return fmt.Errorf("--id is required")
 		}
 
-		client := api.NewClient(cfg.APIURL)
-		err := client.CancelEvent(id)
+		err := apiClient().CancelEvent(cmd.Context(), id)
 		if err != nil {
 			return fmt.Errorf("failed to cancel event: %w", err)
 		}
 
-		fmt.Printf("Event %s cancelled\n", id)
-		return nil
+		return printResult(eventChange{EventID: id, Status: string(sdk.EventCancelled)}, "eventId", "status")
 	},
 }
 
+// eventChange reports the event a command created or changed.
+type eventChange struct {
+	EventID string `json:"eventId"`
+	Status  string `json:"status"`
+}
+
 func init() {
 	// events list flags
-	eventsListCmd.Flags().String("status", "", "Filter by status (active, ended, cancelled)")
+	addEventFilterFlags(eventsListCmd, nil, "")
+	eventsListCmd.Flags().String("sort", "created", "Sort by created (newest first), start, price or popularity (tickets sold)")
+	eventsListCmd.Flags().String("order", "", "asc or desc (defaults to the sort's natural order)")
+	eventsListCmd.Flags().Int("limit", 0, "Events per page (0 for all)")
+	eventsListCmd.Flags().String("cursor", "", "Continue from a previous page (printed when there are more)")
+
+	// events get flags
+	eventsGetCmd.Flags().Bool("skip-chain", false, "Do not cross-check the event against the contract")
 
 	// events create flags
 	eventsCreateCmd.Flags().String("name", "", "Event name (required)")
 	eventsCreateCmd.Flags().String("description", "", "Event description")
-	eventsCreateCmd.Flags().Int64("start", 0, "Start time (unix ms, required)")
-	eventsCreateCmd.Flags().Int64("end", 0, "End time (unix ms, required)")
+	eventsCreateCmd.Flags().String("start", "", `Start time (required): RFC 3339, "2026-11-03 18:00 [Europe/Rome]", "tomorrow 18:00" or unix ms`)
+	eventsCreateCmd.Flags().String("end", "", "End time, in the same forms as --start")
+	eventsCreateCmd.Flags().Duration("duration", 0, "Event length instead of --end, e.g. 3h or 90m")
 	eventsCreateCmd.Flags().Float64("price", 0, "Ticket price in USDC")
 	eventsCreateCmd.Flags().Int("max-tickets", 100, "Maximum tickets available")
 	eventsCreateCmd.Flags().String("team-id", "", "Organizer team ID (required)")
@@ -112,13 +515,31 @@ func init() {
 	eventsCreateCmd.Flags().String("creator", "", "Creator wallet address (defaults to config)")
 	_ = eventsCreateCmd.MarkFlagRequired("name")
 	_ = eventsCreateCmd.MarkFlagRequired("start")
-	_ = eventsCreateCmd.MarkFlagRequired("end")
+	eventsCreateCmd.MarkFlagsMutuallyExclusive("end", "duration")
 	_ = eventsCreateCmd.MarkFlagRequired("team-id")
 
+	// events edit flags
+	eventsEditCmd.Flags().String("name", "", "New event name")
+	eventsEditCmd.Flags().String("description", "", "New description")
+	eventsEditCmd.Flags().String("start", "", "New start time (same forms as events create)")
+	eventsEditCmd.Flags().String("end", "", "New end time (same forms as events create)")
+	eventsEditCmd.Flags().Float64("price", 0, "New ticket price in USDC")
+	eventsEditCmd.Flags().Int("max-tickets", 0, "New maximum tickets (not below those sold)")
+	eventsEditCmd.Flags().String("location", "", "New location")
+	eventsEditCmd.Flags().Bool("skip-chain", false, "Only edit Convex, even when the event is linked on-chain")
+
+	// events link flags
+	eventsLinkCmd.Flags().Int64("onchain-id", 0, "On-chain event ID (required)")
+	eventsLinkCmd.Flags().String("contract", "", "Contract the event was deployed on (defaults to config)")
+	_ = eventsLinkCmd.MarkFlagRequired("onchain-id")
+
 	// events cancel flags
 	eventsCancelCmd.Flags().String("id", "", "Event ID to cancel")
 
 	eventsCmd.AddCommand(eventsListCmd)
+	eventsCmd.AddCommand(eventsGetCmd)
 	eventsCmd.AddCommand(eventsCreateCmd)
+	eventsCmd.AddCommand(eventsEditCmd)
+	eventsCmd.AddCommand(eventsLinkCmd)
 	eventsCmd.AddCommand(eventsCancelCmd)
 }
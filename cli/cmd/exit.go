@@ -0,0 +1,138 @@
+// / cli/cmd/exit.go — Process exit codes and error reporting
+// / Agents branch on the exit code or, with --output json, on the stderr object.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes. These are part of the CLI contract; never renumber them.
+const (
+	exitOK              = 0
+	exitError           = 1  // anything not listed below
+	exitUsage           = 2  // invalid flags or arguments
+	exitUnauthorized    = 3  // no valid API token: buddyevents login
+	exitForbidden       = 4  // signed in without the required role
+	exitNotFound        = 5  // event, ticket or agent does not exist
+	exitInvalidRequest  = 6  // the API rejected the parameters
+	exitConflict        = 7  // already registered / recorded
+	exitSoldOut         = 8  // no tickets left
+	exitEventInactive   = 9  // event is not on sale
+	exitPaymentRequired = 10 // x402 payment missing or rejected
+	exitRateLimited     = 11 // still throttled after retries
+	exitServerError     = 12 // the API failed
+	exitNetwork         = 13 // timed out or could not connect
+)
+
+var apiExitCodes = map[sdk.ErrorCode]int{
+	sdk.CodeUnauthorized:    exitUnauthorized,
+	sdk.CodeForbidden:       exitForbidden,
+	sdk.CodeAdminRequired:   exitForbidden,
+	sdk.CodeNotFound:        exitNotFound,
+	sdk.CodeInvalidRequest:  exitInvalidRequest,
+	sdk.CodeConflict:        exitConflict,
+	sdk.CodeSoldOut:         exitSoldOut,
+	sdk.CodeEventInactive:   exitEventInactive,
+	sdk.CodePaymentRequired: exitPaymentRequired,
+	sdk.CodeRateLimited:     exitRateLimited,
+	sdk.CodeServerError:     exitServerError,
+}
+
+// usageError marks errors caused by how the command was invoked.
+type usageError struct{ error }
+
+func (e usageError) Unwrap() error { return e.error }
+
+// errorReport is the JSON object written to stderr with --output json.
+type errorReport struct {
+	Error    string `json:"error"`
+	Code     string `json:"code"`
+	ExitCode int    `json:"exitCode"`
+	Status   int    `json:"status,omitempty"` // HTTP status of an API error
+	Hint     string `json:"hint,omitempty"`
+}
+
+// classifyError picks the exit code and machine code for err.
+func classifyError(err error) errorReport {
+	report := errorReport{Error: err.Error(), Code: "error", ExitCode: exitError}
+
+	var apiErr *sdk.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &apiErr):
+		report.Code = string(apiErr.Code)
+		report.Status = apiErr.Status
+		if code, ok := apiExitCodes[apiErr.Code]; ok {
+			report.ExitCode = code
+		}
+	case errors.Is(err, chain.ErrSoldOut):
+		report.Code, report.ExitCode = string(sdk.CodeSoldOut), exitSoldOut
+	case errors.Is(err, chain.ErrEventInactive):
+		report.Code, report.ExitCode = string(sdk.CodeEventInactive), exitEventInactive
+	case errors.Is(err, chain.ErrNotOrganizer):
+		report.Code, report.ExitCode = string(sdk.CodeForbidden), exitForbidden
+	case errors.Is(err, chain.ErrPriceLocked):
+		report.Code, report.ExitCode = string(sdk.CodeConflict), exitConflict
+	case errors.As(err, new(usageError)):
+		report.Code, report.ExitCode = "usage", exitUsage
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		report.Code, report.ExitCode = "network", exitNetwork
+	}
+
+	switch {
+	case report.ExitCode == exitUnauthorized:
+		report.Hint = "Run: buddyevents login (or: buddyevents login --wallet)"
+	case errors.Is(err, chain.ErrNotOrganizer):
+		report.Hint = "Only the organizer's wallet can edit the event on-chain: select it with --account, or pass --skip-chain to edit Convex only"
+	case report.ExitCode == exitForbidden:
+		report.Hint = "The logged-in account lacks the role for this call. Log in as another user with: buddyevents login"
+	case apiErr != nil && apiErr.Hint != "":
+		report.Hint = apiErr.Hint
+	}
+	return report
+}
+
+// reportError prints err for a human or, with --output json, as an
+// errorReport, and returns the exit code.
+func reportError(err error) int {
+	report := classifyError(err)
+	if output, _ := rootCmd.PersistentFlags().GetString("output"); output == "json" {
+		data, _ := json.Marshal(report)
+		fmt.Fprintln(os.Stderr, string(data))
+		return report.ExitCode
+	}
+	fmt.Fprintln(os.Stderr, "Error:", report.Error)
+	if report.Hint != "" {
+		fmt.Fprintln(os.Stderr, report.Hint)
+	}
+	return report.ExitCode
+}
+
+func flagError(cmd *cobra.Command, err error) error {
+	return usageError{fmt.Errorf("%w\nSee: %s --help", err, cmd.CommandPath())}
+}
+
+// wrapArgs makes every positional-argument check return a usageError.
+func wrapArgs(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return flagError(c, err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		wrapArgs(sub)
+	}
+}
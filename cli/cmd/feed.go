@@ -0,0 +1,431 @@
+// / cli/cmd/feed.go — Event feeds for calendar apps and feed readers
+// / events export renders iCalendar, RSS or JSON Feed; events serve-feed serves them over HTTP with caching.
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+// feedFormats maps each --format to its Content-Type.
+var feedFormats = map[string]string{
+	"ics":       "text/calendar; charset=utf-8",
+	"rss":       "application/rss+xml; charset=utf-8",
+	"json-feed": "application/feed+json; charset=utf-8",
+}
+
+// feedInfo describes a feed as a whole.
+type feedInfo struct {
+	title   string
+	baseURL string        // web app, for event links
+	feedURL string        // where the feed itself is served; empty for files
+	refresh time.Duration // suggested polling interval; 0 to leave out
+	stamp   time.Time     // when the events were fetched, for DTSTAMP; zero for now
+}
+
+// ===== events export =====
+var eventsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export events as an iCalendar, RSS or JSON Feed file",
+	Long: `Render the events matching the filters as a feed, to --file or stdout.
+Import the .ics into a calendar app, or use events serve-feed to subscribe.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		path, _ := cmd.Flags().GetString("file")
+		title, _ := cmd.Flags().GetString("title")
+		if _, ok := feedFormats[format]; !ok {
+			return usageError{fmt.Errorf("unknown --format %q (want ics, rss or json-feed)", format)}
+		}
+
+		ctx := cmd.Context()
+		filter, err := eventFilterFlags(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		events, err := filter.list(ctx, apiClient())
+		if err != nil {
+			return err
+		}
+
+		info := feedInfo{title: title, baseURL: cfg.APIURL, stamp: time.Now()}
+		if path == "" {
+			return renderFeed(os.Stdout, format, info, events)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := renderFeed(f, format, info, events); err != nil {
+			f.Close()
+			return err
+		}
+		// A failed close can lose buffered data, so it fails the export.
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		progress("Wrote %d events to %s", len(events), path)
+		return nil
+	},
+}
+
+// ===== events serve-feed =====
+var eventsServeFeedCmd = &cobra.Command{
+	Use:   "serve-feed",
+	Short: "Serve a live iCalendar feed that calendar apps can subscribe to",
+	Long: `Serve the events matching the filters over HTTP:
+
+  /events.ics   iCalendar, for calendar subscriptions
+  /events.rss   RSS 2.0
+  /feed.json    JSON Feed 1.1
+
+Events are fetched from the API at most once per --refresh; when a refresh
+fails the last good copy is served. Responses carry an ETag, so clients that
+poll often get 304 Not Modified until an event changes.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+		title, _ := cmd.Flags().GetString("title")
+		if refresh <= 0 {
+			return usageError{fmt.Errorf("--refresh must be positive")}
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		filter, err := eventFilterFlags(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
+		feeds := &feedServer{
+			ctx:   ctx,
+			info:  feedInfo{title: title, baseURL: cfg.APIURL, refresh: refresh},
+			cache: &feedCache{client: apiClient(), filter: filter, ttl: refresh},
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/events.ics", feeds.handler("ics"))
+		mux.Handle("/events.rss", feeds.handler("rss"))
+		mux.Handle("/feed.json", feeds.handler("json-feed"))
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		progress("Serving http://%s/events.ics (refresh every %s); Ctrl-C to stop", listener.Addr(), refresh)
+
+		done := make(chan error, 1)
+		go func() { done <- server.Serve(listener) }()
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-done; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// feedCache holds the filtered events for ttl, so calendar apps polling
+// the feed do not each reach the API.
+type feedCache struct {
+	client *sdk.Client
+	filter eventFilter
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	events  []sdk.Event
+	fetched time.Time // zero until the first successful fetch
+}
+
+// get returns the cached events and when they were fetched, refreshing them
+// when older than ttl. Requests arriving during a refresh wait for it
+// instead of adding their own.
+func (c *feedCache) get(ctx context.Context) ([]sdk.Event, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.fetched.IsZero() && time.Since(c.fetched) < c.ttl {
+		return c.events, c.fetched, nil
+	}
+	events, err := c.filter.list(ctx, c.client)
+	if err != nil {
+		if c.fetched.IsZero() {
+			return nil, time.Time{}, err
+		}
+		progress("warning: refresh failed, serving events fetched at %s: %v", c.fetched.Format(time.RFC3339), err)
+		c.fetched = time.Now() // retry after another ttl rather than on every request
+		return c.events, c.fetched, nil
+	}
+	c.events, c.fetched = events, time.Now()
+	return events, c.fetched, nil
+}
+
+type feedServer struct {
+	ctx   context.Context // fetches outlive the request that triggered them
+	info  feedInfo
+	cache *feedCache
+}
+
+func (s *feedServer) handler(format string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		events, fetched, err := s.cache.get(s.ctx)
+		if err != nil {
+			http.Error(w, "events unavailable: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		// Stamping with the fetch time keeps the body, and so the ETag, the
+		// same until the next refresh.
+		info := s.info
+		info.feedURL = "http://" + r.Host + r.URL.Path
+		info.stamp = fetched
+		var buf bytes.Buffer
+		if err := renderFeed(&buf, format, info, events); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", feedFormats[format])
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.cache.ttl.Seconds())))
+		sum := sha256.Sum256(buf.Bytes())
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sum[:8]))
+		// ServeContent answers If-None-Match with 304 and HEAD without a body.
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(buf.Bytes()))
+	})
+}
+
+// renderFeed writes events in format, one of feedFormats.
+func renderFeed(w io.Writer, format string, feed feedInfo, events []sdk.Event) error {
+	switch format {
+	case "ics":
+		return writeICal(w, feed, events)
+	case "rss":
+		return writeRSS(w, feed, events)
+	case "json-feed":
+		return writeJSONFeed(w, feed, events)
+	}
+	return fmt.Errorf("unknown feed format %q", format)
+}
+
+// eventURL is the web app page of event.
+func eventURL(baseURL string, event sdk.Event) string {
+	return strings.TrimRight(baseURL, "/") + "/events/" + url.PathEscape(event.ID)
+}
+
+// feedDescription is the event description followed by its price and
+// remaining capacity, which feed readers and calendar apps have no field for.
+func feedDescription(event sdk.Event) string {
+	price := "Free"
+	if event.Price > 0 {
+		price = fmt.Sprintf("%g USDC", event.Price)
+	}
+	details := fmt.Sprintf("%s, %d of %d tickets left", price, event.TicketsLeft(), event.MaxTickets)
+	if event.Status == sdk.EventCancelled {
+		details = "Cancelled"
+	}
+	if event.Description == "" {
+		return details
+	}
+	return event.Description + "\n\n" + details
+}
+
+// ===== RSS 2.0 =====
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	TTL           int       `xml:"ttl,omitempty"` // minutes
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// writeRSS writes events as RSS 2.0 items, published when the event was
+// created. Each description leads with when and where the event is.
+func writeRSS(w io.Writer, feed feedInfo, events []sdk.Event) error {
+	channel := rssChannel{
+		Title:       feed.title,
+		Link:        feed.baseURL,
+		Description: "Events on " + feed.title,
+		TTL:         int(feed.refresh.Minutes()),
+	}
+	var latest sdk.Millis
+	for _, event := range events {
+		latest = max(latest, event.CreationTime)
+		item := rssItem{
+			Title:       event.Name,
+			Description: feedWhenWhere(event) + "\n\n" + feedDescription(event),
+			GUID:        rssGUID{Value: event.ID},
+			PubDate:     event.CreationTime.Time().UTC().Format(time.RFC1123Z),
+			Category:    string(event.Status),
+		}
+		if feed.baseURL != "" {
+			item.Link = eventURL(feed.baseURL, event)
+		}
+		channel.Items = append(channel.Items, item)
+	}
+	if latest > 0 {
+		channel.LastBuildDate = latest.Time().UTC().Format(time.RFC1123Z)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(rssFeed{Version: "2.0", Channel: channel}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// feedWhenWhere is the start, end and location of event in the user's zone.
+func feedWhenWhere(event sdk.Event) string {
+	loc := userZone()
+	start, end := event.StartTime.Time().In(loc), event.EndTime.Time().In(loc)
+	when := start.Format("Mon 2 Jan 2006 15:04") + " – " + end.Format("15:04 MST")
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+		when = start.Format("Mon 2 Jan 2006 15:04") + " – " + end.Format("Mon 2 Jan 2006 15:04 MST")
+	}
+	if event.Location == "" {
+		return when
+	}
+	return when + ", " + event.Location
+}
+
+// ===== JSON Feed 1.1 =====
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string        `json:"id"`
+	URL           string        `json:"url,omitempty"`
+	Title         string        `json:"title"`
+	ContentText   string        `json:"content_text"`
+	DatePublished string        `json:"date_published"`
+	Tags          []string      `json:"tags,omitempty"`
+	Event         jsonFeedEvent `json:"_buddyevents"` // JSON Feed extension
+}
+
+// jsonFeedEvent carries the event fields that JSON Feed has no place for.
+type jsonFeedEvent struct {
+	StartTime      string  `json:"startTime"` // RFC 3339
+	EndTime        string  `json:"endTime"`
+	Location       string  `json:"location"`
+	Price          float64 `json:"price"` // USDC
+	TicketsLeft    int     `json:"ticketsLeft"`
+	MaxTickets     int     `json:"maxTickets"`
+	Status         string  `json:"status"`
+	OnChainEventID *int64  `json:"onChainEventId,omitempty"`
+}
+
+func writeJSONFeed(w io.Writer, feed feedInfo, events []sdk.Event) error {
+	out := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.title,
+		HomePageURL: feed.baseURL,
+		FeedURL:     feed.feedURL,
+		Items:       []jsonFeedItem{},
+	}
+	for _, event := range events {
+		item := jsonFeedItem{
+			ID:            event.ID,
+			Title:         event.Name,
+			ContentText:   feedWhenWhere(event) + "\n\n" + feedDescription(event),
+			DatePublished: event.CreationTime.Time().UTC().Format(time.RFC3339),
+			Tags:          []string{string(event.Status)},
+			Event: jsonFeedEvent{
+				StartTime:      event.StartTime.Time().UTC().Format(time.RFC3339),
+				EndTime:        event.EndTime.Time().UTC().Format(time.RFC3339),
+				Location:       event.Location,
+				Price:          event.Price,
+				TicketsLeft:    event.TicketsLeft(),
+				MaxTickets:     event.MaxTickets,
+				Status:         string(event.Status),
+				OnChainEventID: event.OnChainEventID,
+			},
+		}
+		if feed.baseURL != "" {
+			item.URL = eventURL(feed.baseURL, event)
+		}
+		out.Items = append(out.Items, item)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func init() {
+	// events export flags
+	addEventFilterFlags(eventsExportCmd, publicStatuses, publicModeration)
+	eventsExportCmd.Flags().String("format", "ics", "Feed format: ics, rss or json-feed")
+	eventsExportCmd.Flags().String("file", "", "Write to this file instead of stdout")
+	eventsExportCmd.Flags().String("title", "BuddyEvents", "Calendar or feed title")
+
+	// events serve-feed flags
+	addEventFilterFlags(eventsServeFeedCmd, publicStatuses, publicModeration)
+	eventsServeFeedCmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on")
+	eventsServeFeedCmd.Flags().Duration("refresh", 5*time.Minute, "How long events are cached before the API is asked again")
+	eventsServeFeedCmd.Flags().String("title", "BuddyEvents", "Calendar or feed title")
+
+	eventsCmd.AddCommand(eventsExportCmd)
+	eventsCmd.AddCommand(eventsServeFeedCmd)
+}
@@ -0,0 +1,169 @@
+// / cli/cmd/filter.go — Event filters shared by the commands that list events
+// / --status, --moderation, --team, --project, --from/--to, --max-price, --has-seats, --location and --query.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+// publicStatuses are the statuses feeds show by default: drafts are left out.
+var publicStatuses = []sdk.EventStatus{sdk.EventActive, sdk.EventEnded, sdk.EventCancelled}
+
+// publicModeration is the moderation state feeds show by default, so
+// pending and rejected submissions are not published.
+const publicModeration = sdk.ModerationApproved
+
+// eventFilter selects events. The API applies it, and match applies it again
+// to what comes back, so an API that ignores a filter cannot widen the
+// results.
+type eventFilter struct {
+	query sdk.EventQuery // filter fields only; no sort or page
+}
+
+// addEventFilterFlags adds the filter flags to cmd. defaultStatuses apply
+// when --status is not given; nil means every status. defaultModeration
+// applies when --moderation is not given; "" means every moderation state.
+func addEventFilterFlags(cmd *cobra.Command, defaultStatuses []sdk.EventStatus, defaultModeration sdk.ModerationStatus) {
+	statuses := make([]string, len(defaultStatuses))
+	for i, s := range defaultStatuses {
+		statuses[i] = string(s)
+	}
+	moderation := string(defaultModeration)
+	if moderation == "" {
+		moderation = "all"
+	}
+	flags := cmd.Flags()
+	flags.StringSlice("status", statuses, "Only these statuses: draft, active, ended, cancelled")
+	flags.String("moderation", moderation, "Only this moderation state: approved, pending, rejected or all")
+	flags.String("team", "", "Only events of this team (ID or name)")
+	flags.String("project", "", "Only events of this project (ID or name)")
+	flags.String("from", "", `Only events ending after this time (RFC 3339, "2026-11-03", "today"...)`)
+	flags.String("to", "", "Only events starting before this time (same forms as --from)")
+	flags.Float64("max-price", 0, "Only events costing at most this many USDC")
+	flags.Bool("has-seats", false, "Only events with tickets left")
+	flags.String("location", "", "Only events whose location contains this text")
+	flags.StringP("query", "q", "", "Only events with every word of this text in their name or description")
+}
+
+// eventFilterFlags reads the flags added by addEventFilterFlags, resolving
+// team and project names.
+func eventFilterFlags(ctx context.Context, cmd *cobra.Command) (eventFilter, error) {
+	flags := cmd.Flags()
+	var q sdk.EventQuery
+
+	statuses, _ := flags.GetStringSlice("status")
+	for _, s := range statuses {
+		status := sdk.EventStatus(strings.ToLower(strings.TrimSpace(s)))
+		switch status {
+		case sdk.EventDraft, sdk.EventActive, sdk.EventEnded, sdk.EventCancelled:
+			q.Statuses = append(q.Statuses, status)
+		default:
+			return eventFilter{}, usageError{fmt.Errorf("unknown --status %q (want draft, active, ended or cancelled)", s)}
+		}
+	}
+	moderation, _ := flags.GetString("moderation")
+	switch status := sdk.ModerationStatus(strings.ToLower(strings.TrimSpace(moderation))); status {
+	case sdk.ModerationApproved, sdk.ModerationPending, sdk.ModerationRejected:
+		q.ModerationStatus = status
+	case "all":
+	default:
+		return eventFilter{}, usageError{fmt.Errorf("unknown --moderation %q (want approved, pending, rejected or all)", moderation)}
+	}
+
+	refs := &refResolver{client: apiClient()}
+	var err error
+	if team, _ := flags.GetString("team"); team != "" {
+		if q.TeamID, err = refs.team(ctx, team); err != nil {
+			return eventFilter{}, err
+		}
+	}
+	if project, _ := flags.GetString("project"); project != "" {
+		if q.ProjectID, err = refs.project(ctx, project); err != nil {
+			return eventFilter{}, err
+		}
+	}
+	if flags.Changed("from") {
+		if q.From, err = timeFlag(cmd, "from", userZone()); err != nil {
+			return eventFilter{}, err
+		}
+	}
+	if flags.Changed("to") {
+		if q.To, err = timeFlag(cmd, "to", userZone()); err != nil {
+			return eventFilter{}, err
+		}
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.To.After(q.From) {
+		return eventFilter{}, usageError{fmt.Errorf("--to must be after --from")}
+	}
+	if flags.Changed("max-price") {
+		maxPrice, _ := flags.GetFloat64("max-price")
+		if maxPrice < 0 {
+			return eventFilter{}, usageError{fmt.Errorf("--max-price must not be negative")}
+		}
+		q.MaxPrice = &maxPrice
+	}
+	q.HasSeats, _ = flags.GetBool("has-seats")
+	q.Location, _ = flags.GetString("location")
+	q.Text, _ = flags.GetString("query")
+	return eventFilter{query: q}, nil
+}
+
+func (f eventFilter) match(event sdk.Event) bool {
+	q := f.query
+	switch {
+	case len(q.Statuses) > 0 && !slices.Contains(q.Statuses, event.Status):
+		return false
+	case q.ModerationStatus != "" && moderationStatus(event) != q.ModerationStatus:
+		return false
+	case q.TeamID != "" && event.TeamID != q.TeamID:
+		return false
+	case q.ProjectID != "" && event.ProjectID != q.ProjectID:
+		return false
+	case !q.From.IsZero() && !event.EndTime.Time().After(q.From):
+		return false
+	case !q.To.IsZero() && !event.StartTime.Time().Before(q.To):
+		return false
+	case q.MaxPrice != nil && event.Price > *q.MaxPrice:
+		return false
+	case q.HasSeats && event.TicketsLeft() == 0:
+		return false
+	case q.Location != "" && !strings.Contains(strings.ToLower(event.Location), strings.ToLower(strings.TrimSpace(q.Location))):
+		return false
+	}
+	text := strings.ToLower(event.Name + "\n" + event.Description)
+	for _, word := range strings.Fields(strings.ToLower(q.Text)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// search fetches one page of the events matching f, in the order and size
+// given by page (its filter fields are ignored).
+func (f eventFilter) search(ctx context.Context, client *sdk.Client, page sdk.EventQuery) (*sdk.EventPage, error) {
+	q := f.query
+	q.Sort, q.Order, q.Limit, q.Cursor = page.Sort, page.Order, page.Limit, page.Cursor
+	result, err := client.SearchEvents(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	result.Events = slices.DeleteFunc(result.Events, func(e sdk.Event) bool { return !f.match(e) })
+	return result, nil
+}
+
+// list fetches every event matching f, soonest first.
+func (f eventFilter) list(ctx context.Context, client *sdk.Client) ([]sdk.Event, error) {
+	result, err := f.search(ctx, client, sdk.EventQuery{Sort: sdk.SortStart})
+	if err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
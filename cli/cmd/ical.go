@@ -0,0 +1,285 @@
+// / cli/cmd/ical.go — Minimal iCalendar (RFC 5545) reader and writer
+// / Reads the VEVENT properties events import needs; writes the feeds of events export and serve-feed.
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+)
+
+// icalEvent is one VEVENT. Start and End are zero when missing.
+type icalEvent struct {
+	Line        int // line of BEGIN:VEVENT
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Status      string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Err         error // first property that could not be read
+}
+
+// icalProperty is one unfolded content line: NAME;PARAM=V;...:VALUE.
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// readICal returns the VEVENTs of a calendar. Times without a zone or TZID
+// ("floating" times) are read in loc. A malformed event is returned with
+// Err set rather than failing the whole calendar.
+func readICal(r io.Reader, loc *time.Location) ([]icalEvent, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []icalEvent
+	var current *icalEvent
+	var duration string
+	for _, line := range lines {
+		prop := parseICalLine(line.text)
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current, duration = &icalEvent{Line: line.number}, ""
+		case current == nil:
+			continue
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if duration != "" && current.End.IsZero() && current.Err == nil {
+				d, err := parseICalDuration(duration)
+				if err != nil {
+					current.Err = fmt.Errorf("DURATION: %w", err)
+				} else {
+					current.End = current.Start.Add(d)
+				}
+			}
+			if current.End.IsZero() && current.AllDay && !current.Start.IsZero() {
+				current.End = current.Start.AddDate(0, 0, 1)
+			}
+			events = append(events, *current)
+			current = nil
+		case prop.name == "UID":
+			current.UID = prop.value
+		case prop.name == "SUMMARY":
+			current.Summary = unescapeICalText(prop.value)
+		case prop.name == "DESCRIPTION":
+			current.Description = unescapeICalText(prop.value)
+		case prop.name == "LOCATION":
+			current.Location = unescapeICalText(prop.value)
+		case prop.name == "STATUS":
+			current.Status = strings.ToUpper(prop.value)
+		case prop.name == "DURATION":
+			duration = prop.value
+		case prop.name == "DTSTART", prop.name == "DTEND":
+			t, allDay, err := parseICalTime(prop, loc)
+			if err != nil {
+				if current.Err == nil {
+					current.Err = fmt.Errorf("%s: %w", prop.name, err)
+				}
+				continue
+			}
+			if prop.name == "DTSTART" {
+				current.Start, current.AllDay = t, allDay
+			} else {
+				current.End = t
+			}
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("line %d: VEVENT is not closed with END:VEVENT", current.Line)
+	}
+	return events, nil
+}
+
+type icalLine struct {
+	number int
+	text   string
+}
+
+// unfoldICal joins continuation lines, which start with a space or tab, to
+// the line before them.
+func unfoldICal(r io.Reader) ([]icalLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []icalLine
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, icalLine{number: n, text: text})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func parseICalLine(line string) icalProperty {
+	// The value starts at the first colon outside a quoted parameter value.
+	quoted, colon := false, -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icalProperty{name: strings.ToUpper(line)}
+	}
+	parts := strings.Split(line[:colon], ";")
+	prop := icalProperty{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop
+}
+
+// parseICalTime reads a DATE or DATE-TIME value: UTC with a trailing Z, in
+// the TZID zone, or floating.
+func parseICalTime(prop icalProperty, loc *time.Location) (time.Time, bool, error) {
+	if tzid := prop.params["TZID"]; tzid != "" {
+		zone, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		loc = zone
+	}
+	value := strings.TrimSpace(prop.value)
+	if prop.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalDuration reads an RFC 5545 duration such as PT2H30M or P1D.
+func parseICalDuration(value string) (time.Duration, error) {
+	m := icalDurationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] != "" {
+			n, _ := strconv.Atoi(m[i+2])
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var icalTextUnescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeICalText(value string) string {
+	return icalTextUnescaper.Replace(value)
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// writeICal writes events as a VCALENDAR. UIDs are stable so that calendar
+// apps update events in place, SEQUENCE and LAST-MODIFIED tell them an event
+// changed, and cancelled events keep their VEVENT with STATUS:CANCELLED so
+// that subscribers drop them.
+func writeICal(w io.Writer, feed feedInfo, events []sdk.Event) error {
+	bw := bufio.NewWriter(w)
+	stamp := feed.stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	line := func(name, value string) {
+		writeICalLine(bw, name+":"+value)
+	}
+	utc := func(m sdk.Millis) string {
+		return m.Time().UTC().Format("20060102T150405Z")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//BuddyEvents//buddyevents CLI//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", icalTextEscaper.Replace(feed.title))
+	if feed.refresh > 0 {
+		minutes := max(int(feed.refresh.Minutes()), 1)
+		line("REFRESH-INTERVAL;VALUE=DURATION", fmt.Sprintf("PT%dM", minutes))
+		line("X-PUBLISHED-TTL", fmt.Sprintf("PT%dM", minutes))
+	}
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.ID+"@buddyevents")
+		line("DTSTAMP", utc(sdk.Millis(stamp.UnixMilli())))
+		modified := event.UpdatedAt
+		if modified == 0 {
+			modified = event.CreationTime
+		}
+		line("CREATED", utc(event.CreationTime))
+		line("LAST-MODIFIED", utc(modified))
+		line("SEQUENCE", strconv.Itoa(event.Sequence))
+		line("DTSTART", utc(event.StartTime))
+		line("DTEND", utc(event.EndTime))
+		line("SUMMARY", icalTextEscaper.Replace(event.Name))
+		line("DESCRIPTION", icalTextEscaper.Replace(feedDescription(event)))
+		if event.Location != "" {
+			line("LOCATION", icalTextEscaper.Replace(event.Location))
+		}
+		if feed.baseURL != "" {
+			line("URL", eventURL(feed.baseURL, event))
+		}
+		switch event.Status {
+		case sdk.EventCancelled:
+			line("STATUS", "CANCELLED")
+		case sdk.EventDraft:
+			line("STATUS", "TENTATIVE")
+		default:
+			line("STATUS", "CONFIRMED")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// writeICalLine writes a content line folded at 75 octets, without splitting
+// a UTF-8 sequence, and ended with CRLF.
+func writeICalLine(w *bufio.Writer, text string) {
+	limit := 75
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		w.WriteString(text[:cut])
+		w.WriteString("\r\n ")
+		text = text[cut:]
+		limit = 74 // the leading space counts
+	}
+	w.WriteString(text)
+	w.WriteString("\r\n")
+}
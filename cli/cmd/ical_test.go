@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+)
+
+func TestReadICal(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	tests := []struct {
+		name        string
+		vevent      string // properties between BEGIN:VEVENT and END:VEVENT
+		summary     string
+		description string
+		location    string
+		start, end  time.Time
+		allDay      bool
+		wantErr     string // substring of icalEvent.Err
+	}{
+		{
+			name:    "utc",
+			vevent:  "SUMMARY:Meetup\r\nDTSTART:20261103T170000Z\r\nDTEND:20261103T200000Z",
+			summary: "Meetup",
+			start:   time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+			end:     time.Date(2026, 11, 3, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "folded lines",
+			vevent:  "SUMMARY:Buddy\r\n Events\r\n\tRome\r\nDTSTART:20261103T170000Z\r\nDTEND:20261103T\r\n 200000Z",
+			summary: "BuddyEventsRome",
+			start:   time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+			end:     time.Date(2026, 11, 3, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "tzid",
+			vevent:  "SUMMARY:Rome\r\nDTSTART;TZID=Europe/Rome:20261103T180000\r\nDTEND;TZID=\"Europe/Rome\":20261103T210000",
+			summary: "Rome",
+			start:   time.Date(2026, 11, 3, 18, 0, 0, 0, rome),
+			end:     time.Date(2026, 11, 3, 21, 0, 0, 0, rome),
+		},
+		{
+			name:    "floating times use the reader's zone",
+			vevent:  "SUMMARY:Local\r\nDTSTART:20261103T180000\r\nDTEND:20261103T190000",
+			summary: "Local",
+			start:   time.Date(2026, 11, 3, 18, 0, 0, 0, newYork),
+			end:     time.Date(2026, 11, 3, 19, 0, 0, 0, newYork),
+		},
+		{
+			name:    "all day without end lasts a day",
+			vevent:  "SUMMARY:Fair\r\nDTSTART;VALUE=DATE:20261103",
+			summary: "Fair",
+			start:   time.Date(2026, 11, 3, 0, 0, 0, 0, newYork),
+			end:     time.Date(2026, 11, 4, 0, 0, 0, 0, newYork),
+			allDay:  true,
+		},
+		{
+			name:    "duration",
+			vevent:  "SUMMARY:Talk\r\nDTSTART:20261103T170000Z\r\nDURATION:PT1H30M",
+			summary: "Talk",
+			start:   time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+			end:     time.Date(2026, 11, 3, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "dtend wins over duration",
+			vevent:  "SUMMARY:Talk\r\nDTSTART:20261103T170000Z\r\nDURATION:PT5H\r\nDTEND:20261103T180000Z",
+			summary: "Talk",
+			start:   time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+			end:     time.Date(2026, 11, 3, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "escaped text",
+			vevent:      `SUMMARY:Rome\, Italy` + "\r\n" + `DESCRIPTION:Line one\nLine two\; with \\ backslash` + "\r\n" + `LOCATION:Via Roma 1\, Roma` + "\r\nDTSTART:20261103T170000Z",
+			summary:     "Rome, Italy",
+			description: "Line one\nLine two; with \\ backslash",
+			location:    "Via Roma 1, Roma",
+			start:       time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "unknown tzid",
+			vevent:  "SUMMARY:Nowhere\r\nDTSTART;TZID=Mars/Olympus:20261103T180000",
+			summary: "Nowhere",
+			wantErr: `DTSTART: unknown TZID "Mars/Olympus"`,
+		},
+		{
+			name:    "bad duration",
+			vevent:  "SUMMARY:Talk\r\nDTSTART:20261103T170000Z\r\nDURATION:PT",
+			summary: "Talk",
+			start:   time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC),
+			wantErr: "DURATION: invalid duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n" + tt.vevent + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+			events, err := readICal(strings.NewReader(calendar), newYork)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+			got := events[0]
+			if got.Line != 2 {
+				t.Errorf("Line = %d, want 2", got.Line)
+			}
+			if tt.wantErr != "" {
+				if got.Err == nil || !strings.Contains(got.Err.Error(), tt.wantErr) {
+					t.Errorf("Err = %v, want %q", got.Err, tt.wantErr)
+				}
+			} else if got.Err != nil {
+				t.Errorf("Err = %v", got.Err)
+			}
+			if got.Summary != tt.summary || got.Description != tt.description || got.Location != tt.location {
+				t.Errorf("text = %q / %q / %q, want %q / %q / %q",
+					got.Summary, got.Description, got.Location, tt.summary, tt.description, tt.location)
+			}
+			if !got.Start.Equal(tt.start) || !got.End.Equal(tt.end) || got.AllDay != tt.allDay {
+				t.Errorf("times = %s – %s (all day %t), want %s – %s (all day %t)",
+					got.Start, got.End, got.AllDay, tt.start, tt.end, tt.allDay)
+			}
+		})
+	}
+}
+
+func TestReadICalUnclosed(t *testing.T) {
+	_, err := readICal(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Open\n"), time.UTC)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want the unclosed VEVENT on line 2", err)
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"PT2H30M", 2*time.Hour + 30*time.Minute, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P1W", 7 * 24 * time.Hour, false},
+		{"P1DT1S", 24*time.Hour + time.Second, false},
+		{"-PT15M", -15 * time.Minute, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"2H", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseICalDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseICalDuration(%q) = %s, %v; want %s, error %t", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteICalRoundTrip(t *testing.T) {
+	stamp := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	created := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 9, 20, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC)
+	events := []sdk.Event{{
+		ID:           "ev1",
+		CreationTime: sdk.Millis(created.UnixMilli()),
+		UpdatedAt:    sdk.Millis(updated.UnixMilli()),
+		Sequence:     3,
+		Name:         `Rome, Italy; "BuddyEvents" \ Monad — un nome molto lungo con àccenti e emoji 🎉 che va piegato`,
+		Description:  "First line\nSecond line, with; punctuation \\ and more",
+		Location:     "Via del Corso 1, Roma; piano 2",
+		StartTime:    sdk.Millis(start.UnixMilli()),
+		EndTime:      sdk.Millis(start.Add(3 * time.Hour).UnixMilli()),
+		Status:       sdk.EventActive,
+	}}
+
+	var buf bytes.Buffer
+	if err := writeICal(&buf, feedInfo{title: "Feed", stamp: stamp}, events); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.String()
+	for _, line := range strings.Split(strings.TrimSuffix(raw, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("fold split a UTF-8 sequence: %q", line)
+		}
+	}
+	for _, want := range []string{"DTSTAMP:20261001T093000Z", "CREATED:20260901T120000Z", "LAST-MODIFIED:20260920T080000Z", "SEQUENCE:3"} {
+		if !strings.Contains(raw, want+"\r\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+
+	got, err := readICal(strings.NewReader(raw), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+	if got[0].Err != nil {
+		t.Fatal(got[0].Err)
+	}
+	if got[0].UID != "ev1@buddyevents" || got[0].Summary != events[0].Name || got[0].Location != events[0].Location {
+		t.Errorf("read back %q %q %q", got[0].UID, got[0].Summary, got[0].Location)
+	}
+	if !strings.HasPrefix(got[0].Description, events[0].Description+"\n") {
+		t.Errorf("description = %q, want it to start with %q", got[0].Description, events[0].Description)
+	}
+	if !got[0].Start.Equal(start) || !got[0].End.Equal(start.Add(3*time.Hour)) {
+		t.Errorf("times = %s – %s", got[0].Start, got[0].End)
+	}
+}
+
+func TestWriteICalNeverEdited(t *testing.T) {
+	created := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := writeICal(&buf, feedInfo{}, []sdk.Event{{ID: "ev1", CreationTime: sdk.Millis(created.UnixMilli())}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"LAST-MODIFIED:20260901T120000Z", "SEQUENCE:0"} {
+		if !strings.Contains(buf.String(), want+"\r\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,452 @@
+// / cli/cmd/import.go — Bulk event import
+// / events import --from: validate iCalendar or CSV rows, skip duplicates, create the rest in parallel.
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+// ===== events import =====
+var eventsImportCmd = &cobra.Command{
+	Use:   "import --from <file.ics|file.csv>",
+	Short: "Create events in bulk from an iCalendar or CSV file",
+	Long: `Create one event per VEVENT of an iCalendar file or per row of a CSV file.
+
+CSV files need a header row naming their columns: name and start, and any of
+description, end, duration, location, price, maxTickets and team. Times take
+the same forms as events create; cancelled VEVENTs are skipped.
+
+Every row is validated first, and rows matching an existing event (or an
+earlier row) by name, start time and location are reported as duplicates.
+The rest are created --parallel at a time; a failed row does not stop the
+others. The per-row report is printed, and with --report also written as CSV.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		from, _ := flags.GetString("from")
+		format, _ := flags.GetString("format")
+		reportPath, _ := flags.GetString("report")
+		parallel, _ := flags.GetInt("parallel")
+		dryRun, _ := flags.GetBool("dry-run")
+
+		if parallel < 1 {
+			return usageError{fmt.Errorf("--parallel must be at least 1")}
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(from)), ".")
+		}
+		defaults := importDefaults{creator: walletAddress()}
+		defaults.team, _ = flags.GetString("team")
+		defaults.price, _ = flags.GetFloat64("price")
+		defaults.maxTickets, _ = flags.GetInt("max-tickets")
+
+		f, err := os.Open(from)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		var rows []*importCandidate
+		switch format {
+		case "ics", "ical":
+			rows, err = readICalRows(f, defaults)
+		case "csv":
+			rows, err = readCSVRows(f, defaults)
+		default:
+			return usageError{fmt.Errorf("cannot tell the format of %s; pass --format ics or --format csv", from)}
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", from, err)
+		}
+		if len(rows) == 0 {
+			return usageError{fmt.Errorf("%s holds no events", from)}
+		}
+
+		ctx := cmd.Context()
+		client := apiClient()
+		validateImport(ctx, &refResolver{client: client}, rows)
+
+		existing, err := client.ListEvents(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to list events for the duplicate check: %w", err)
+		}
+		markDuplicates(rows, existing)
+
+		if dryRun {
+			for _, row := range rows {
+				if row.Status == "" {
+					row.Status = "valid"
+				}
+			}
+		} else {
+			submitImport(ctx, client, rows, parallel)
+		}
+
+		report := make([]importRow, len(rows))
+		for i, row := range rows {
+			report[i] = row.importRow
+		}
+		if reportPath != "" {
+			if err := writeImportReport(reportPath, report); err != nil {
+				return err
+			}
+		}
+		if err := printResult(report, "line", "name", "start", "status", "eventId", "error"); err != nil {
+			return err
+		}
+
+		var invalid, failed int
+		for _, row := range report {
+			switch row.Status {
+			case "invalid":
+				invalid++
+			case "failed":
+				failed++
+			}
+		}
+		if invalid+failed > 0 {
+			return fmt.Errorf("%d of %d rows not imported (%d invalid, %d failed)", invalid+failed, len(report), invalid, failed)
+		}
+		return nil
+	},
+}
+
+// importRow is one line of the import report.
+type importRow struct {
+	Line     int    `json:"line"` // CSV line, or the line of BEGIN:VEVENT
+	Name     string `json:"name"`
+	Start    string `json:"start"` // RFC 3339 in the --timezone zone
+	Location string `json:"location"`
+	Status   string `json:"status"`  // created, duplicate, invalid, failed or skipped; valid in dry runs
+	EventID  string `json:"eventId"` // the created event, or the existing event a duplicate matches
+	Error    string `json:"error"`
+}
+
+// importCandidate is a row on its way to becoming an event. A row whose
+// Status is set is settled and is not submitted.
+type importCandidate struct {
+	importRow
+	req      sdk.CreateEventRequest
+	team     string        // team ID or name
+	duration time.Duration // used when the row has no end
+}
+
+func (c *importCandidate) reject(status string, err error) {
+	if c.Status == "" {
+		c.Status, c.Error = status, err.Error()
+	}
+}
+
+// importDefaults fill in what a row leaves out.
+type importDefaults struct {
+	team       string
+	price      float64
+	maxTickets int
+	creator    string
+}
+
+func (d importDefaults) candidate(line int) *importCandidate {
+	return &importCandidate{
+		importRow: importRow{Line: line},
+		req: sdk.CreateEventRequest{
+			Price:          d.price,
+			MaxTickets:     d.maxTickets,
+			CreatorAddress: d.creator,
+		},
+		team: d.team,
+	}
+}
+
+func readICalRows(r io.Reader, defaults importDefaults) ([]*importCandidate, error) {
+	events, err := readICal(r, userZone())
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*importCandidate, len(events))
+	for i, event := range events {
+		row := defaults.candidate(event.Line)
+		row.req.Name = strings.TrimSpace(event.Summary)
+		row.req.Description = event.Description
+		row.req.Location = event.Location
+		if !event.Start.IsZero() {
+			row.req.StartTime = event.Start.UnixMilli()
+		}
+		if !event.End.IsZero() {
+			row.req.EndTime = event.End.UnixMilli()
+		}
+		if event.Err != nil {
+			row.reject("invalid", event.Err)
+		}
+		if event.Status == "CANCELLED" {
+			row.reject("skipped", errors.New("cancelled in the calendar"))
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// csvColumns are the CSV columns import reads, keyed by their normalized
+// header (lower case, without spaces, dashes or underscores).
+var csvColumns = []string{"name", "description", "start", "end", "duration", "location", "price", "maxtickets", "team"}
+
+func normalizeColumn(header string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(header)))
+}
+
+func readCSVRows(r io.Reader, defaults importDefaults) ([]*importCandidate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, h := range header {
+		name := normalizeColumn(strings.TrimPrefix(h, "\ufeff"))
+		known := false
+		for _, c := range csvColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, usageError{fmt.Errorf("unknown CSV column %q (want %s)", h, strings.Join(csvColumns, ", "))}
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "start"} {
+		if _, ok := columns[required]; !ok {
+			return nil, usageError{fmt.Errorf("CSV has no %s column", required)}
+		}
+	}
+
+	loc := userZone()
+	var rows []*importCandidate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row := defaults.candidate(parseErr.StartLine)
+			row.reject("invalid", parseErr.Err)
+			rows = append(rows, row)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvCandidate(defaults.candidate(line), record, columns, loc))
+	}
+}
+
+func csvCandidate(row *importCandidate, record []string, columns map[string]int, loc *time.Location) *importCandidate {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	row.req.Name = cell("name")
+	row.req.Description = cell("description")
+	row.req.Location = cell("location")
+	if team := cell("team"); team != "" {
+		row.team = team
+	}
+
+	if value := cell("start"); value != "" {
+		if t, err := parseTime(value, loc); err != nil {
+			row.reject("invalid", fmt.Errorf("start: %w", err))
+		} else {
+			row.req.StartTime = t.UnixMilli()
+		}
+	}
+	if value := cell("end"); value != "" {
+		if t, err := parseTime(value, loc); err != nil {
+			row.reject("invalid", fmt.Errorf("end: %w", err))
+		} else {
+			row.req.EndTime = t.UnixMilli()
+		}
+	}
+	if value := cell("duration"); value != "" {
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			row.reject("invalid", fmt.Errorf("duration: invalid %q, want a length such as 3h or 90m", value))
+		} else {
+			row.duration = d
+		}
+	}
+	if value := cell("price"); value != "" {
+		if price, err := strconv.ParseFloat(value, 64); err != nil {
+			row.reject("invalid", fmt.Errorf("price: invalid number %q", value))
+		} else {
+			row.req.Price = price
+		}
+	}
+	if value := cell("maxtickets"); value != "" {
+		if n, err := strconv.Atoi(value); err != nil {
+			row.reject("invalid", fmt.Errorf("maxTickets: invalid number %q", value))
+		} else {
+			row.req.MaxTickets = n
+		}
+	}
+	return row
+}
+
+// validateImport checks what the API would reject, so a bad row is reported
+// without being sent, and resolves team names to IDs.
+func validateImport(ctx context.Context, refs *refResolver, rows []*importCandidate) {
+	now := time.Now().UnixMilli()
+	for _, row := range rows {
+		req := &row.req
+		if req.EndTime == 0 && row.duration > 0 && req.StartTime != 0 {
+			req.EndTime = req.StartTime + row.duration.Milliseconds()
+		}
+
+		row.Name, row.Location = req.Name, req.Location
+		if req.StartTime != 0 {
+			row.Start = time.UnixMilli(req.StartTime).In(userZone()).Format(time.RFC3339)
+		}
+
+		switch {
+		case req.Name == "":
+			row.reject("invalid", errors.New("name is required"))
+		case req.StartTime == 0:
+			row.reject("invalid", errors.New("start is required"))
+		case req.EndTime == 0:
+			row.reject("invalid", errors.New("end or duration is required"))
+		case req.EndTime <= req.StartTime:
+			row.reject("invalid", errors.New("ends before it starts"))
+		case req.StartTime < now:
+			row.reject("invalid", errors.New("starts in the past"))
+		case req.Price < 0:
+			row.reject("invalid", errors.New("price must not be negative"))
+		case req.MaxTickets <= 0:
+			row.reject("invalid", errors.New("maxTickets must be positive"))
+		case row.team == "":
+			row.reject("invalid", errors.New("no team; pass --team or add a team column"))
+		}
+		if row.Status != "" {
+			continue
+		}
+		teamID, err := refs.team(ctx, row.team)
+		if err != nil {
+			row.reject("invalid", err)
+			continue
+		}
+		req.TeamID = teamID
+	}
+}
+
+// importKey identifies an event for the duplicate check.
+func importKey(name string, start int64, location string) string {
+	return fmt.Sprintf("%s\x00%d\x00%s", strings.ToLower(strings.TrimSpace(name)), start, strings.ToLower(strings.TrimSpace(location)))
+}
+
+// markDuplicates settles rows that match an event not cancelled, or an
+// earlier row of the file, by name, start time and location.
+func markDuplicates(rows []*importCandidate, existing []sdk.Event) {
+	seen := map[string]string{}
+	for _, event := range existing {
+		if event.Status != sdk.EventCancelled {
+			seen[importKey(event.Name, int64(event.StartTime), event.Location)] = event.ID
+		}
+	}
+	earlier := map[string]int{}
+	for _, row := range rows {
+		if row.Status != "" {
+			continue
+		}
+		key := importKey(row.req.Name, row.req.StartTime, row.req.Location)
+		if id, ok := seen[key]; ok {
+			row.EventID = id
+			row.reject("duplicate", errors.New("matches an existing event"))
+			continue
+		}
+		if line, ok := earlier[key]; ok {
+			row.reject("duplicate", fmt.Errorf("same as line %d", line))
+			continue
+		}
+		earlier[key] = row.Line
+	}
+}
+
+// submitImport creates the unsettled rows, at most parallel at a time.
+func submitImport(ctx context.Context, client *sdk.Client, rows []*importCandidate, parallel int) {
+	var pending []*importCandidate
+	for _, row := range rows {
+		if row.Status == "" {
+			pending = append(pending, row)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	progress("Submitting %d rows, %d at a time...", len(pending), parallel)
+
+	work := make(chan *importCandidate)
+	var wg sync.WaitGroup
+	for range min(parallel, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range work {
+				eventID, err := client.CreateEvent(ctx, row.req)
+				if err != nil {
+					row.reject("failed", err)
+					continue
+				}
+				row.Status, row.EventID = "created", eventID
+			}
+		}()
+	}
+	for _, row := range pending {
+		work <- row
+	}
+	close(work)
+	wg.Wait()
+}
+
+// writeImportReport writes the report to path as CSV, with every column.
+func writeImportReport(path string, report []importRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	records, _ := recordsOf(report)
+	if err := writeCSV(f, records, fieldNames(reflect.ValueOf(importRow{}))); err != nil {
+		f.Close()
+		return fmt.Errorf("write report: %w", err)
+	}
+	return f.Close()
+}
+
+func init() {
+	eventsImportCmd.Flags().String("from", "", "iCalendar (.ics) or CSV file to import (required)")
+	eventsImportCmd.Flags().String("format", "", "ics or csv (defaults to the file extension)")
+	eventsImportCmd.Flags().String("team", "", "Team ID or name for rows without a team column")
+	eventsImportCmd.Flags().Float64("price", 0, "Ticket price in USDC for rows without a price")
+	eventsImportCmd.Flags().Int("max-tickets", 100, "Maximum tickets for rows without maxTickets")
+	eventsImportCmd.Flags().Int("parallel", 4, "Events created at the same time")
+	eventsImportCmd.Flags().String("report", "", "Also write the per-row report to this CSV file")
+	eventsImportCmd.Flags().Bool("dry-run", false, "Validate and check for duplicates without creating anything")
+	_ = eventsImportCmd.MarkFlagRequired("from")
+
+	eventsCmd.AddCommand(eventsImportCmd)
+}
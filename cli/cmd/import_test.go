@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+)
+
+// withZone runs the test with the timezone config key set to zone.
+func withZone(t *testing.T, zone string) {
+	t.Helper()
+	saved := cfg
+	cfg = &config.Config{Timezone: zone}
+	t.Cleanup(func() { cfg = saved })
+}
+
+func TestReadCSVRows(t *testing.T) {
+	withZone(t, "UTC")
+	defaults := importDefaults{team: "Default Team", price: 1, maxTickets: 50}
+	input := strings.Join([]string{
+		"Name,Start,Duration,Location,Price,Max Tickets,team",
+		"Meetup,2026-11-03T18:00:00Z,2h,Rome,5,120,",
+		`Broken,"2026-11-03 18:00"x,2h,Rome,,,`,
+		"Bad start,next blue moon,2h,,,,",
+		"Bad price,2026-11-04T18:00:00Z,2h,,free,,",
+		"Short row,2026-11-05T18:00:00Z",
+		"Own team,2026-11-06T18:00:00Z,90m,,,,Monad Italia",
+	}, "\n")
+
+	rows, err := readCSVRows(strings.NewReader(input), defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		line       int
+		name       string
+		status     string
+		errSubstr  string
+		price      float64
+		maxTickets int
+		team       string
+	}{
+		{2, "Meetup", "", "", 5, 120, "Default Team"},
+		{3, "", "invalid", "quote", 1, 50, "Default Team"},
+		{4, "Bad start", "invalid", "start:", 1, 50, "Default Team"},
+		{5, "Bad price", "invalid", "price: invalid number", 1, 50, "Default Team"},
+		{6, "Short row", "", "", 1, 50, "Default Team"},
+		{7, "Own team", "", "", 1, 50, "Monad Italia"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, w := range want {
+		row := rows[i]
+		if row.Line != w.line || row.req.Name != w.name || row.Status != w.status || !strings.Contains(row.Error, w.errSubstr) {
+			t.Errorf("row %d = line %d %q %q %q, want line %d %q %q containing %q",
+				i, row.Line, row.req.Name, row.Status, row.Error, w.line, w.name, w.status, w.errSubstr)
+		}
+		if row.req.Price != w.price || row.req.MaxTickets != w.maxTickets || row.team != w.team {
+			t.Errorf("row %d defaults = %v %d %q, want %v %d %q",
+				i, row.req.Price, row.req.MaxTickets, row.team, w.price, w.maxTickets, w.team)
+		}
+	}
+
+	meetup := rows[0]
+	if got, want := meetup.req.StartTime, time.Date(2026, 11, 3, 18, 0, 0, 0, time.UTC).UnixMilli(); got != want {
+		t.Errorf("start = %d, want %d", got, want)
+	}
+	if meetup.duration != 2*time.Hour || meetup.req.Location != "Rome" {
+		t.Errorf("duration, location = %s, %q", meetup.duration, meetup.req.Location)
+	}
+}
+
+func TestReadCSVRowsHeader(t *testing.T) {
+	withZone(t, "UTC")
+	tests := []struct {
+		header  string
+		wantErr string
+	}{
+		{"\ufeffname,start,end", ""},
+		{"NAME, Start ,max_tickets", ""},
+		{"name,start,venue", `unknown CSV column "venue"`},
+		{"name,end", "no start column"},
+	}
+	for _, tt := range tests {
+		_, err := readCSVRows(strings.NewReader(tt.header+"\n"), importDefaults{})
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("header %q: err = %v, want %q", tt.header, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMarkDuplicates(t *testing.T) {
+	start := time.Date(2026, 11, 3, 18, 0, 0, 0, time.UTC).UnixMilli()
+	existing := []sdk.Event{
+		{ID: "live", Name: "Meetup", StartTime: sdk.Millis(start), Location: "Rome", Status: sdk.EventActive},
+		{ID: "gone", Name: "Workshop", StartTime: sdk.Millis(start), Location: "Rome", Status: sdk.EventCancelled},
+	}
+	row := func(line int, name string, start int64, location, status string) *importCandidate {
+		c := &importCandidate{importRow: importRow{Line: line, Status: status}}
+		c.req.Name, c.req.StartTime, c.req.Location = name, start, location
+		return c
+	}
+	rows := []*importCandidate{
+		row(2, " meetup ", start, "ROME", ""),             // matches a live event, ignoring case and spaces
+		row(3, "Workshop", start, "Rome", ""),             // the match is cancelled
+		row(4, "Workshop", start, "Rome", ""),             // same as line 3
+		row(5, "Meetup", start+60_000, "Rome", ""),        // another start
+		row(6, "Meetup", start+60_000, "Rome", "invalid"), // settled rows are left alone
+		row(7, "Meetup", start+60_000, "Milan", ""),       // another location
+	}
+	markDuplicates(rows, existing)
+
+	want := []struct {
+		status, eventID, err string
+	}{
+		{"duplicate", "live", "matches an existing event"},
+		{"", "", ""},
+		{"duplicate", "", "same as line 3"},
+		{"", "", ""},
+		{"invalid", "", ""},
+		{"", "", ""},
+	}
+	for i, w := range want {
+		got := rows[i]
+		if got.Status != w.status || got.EventID != w.eventID || got.Error != w.err {
+			t.Errorf("line %d = %q %q %q, want %q %q %q", got.Line, got.Status, got.EventID, got.Error, w.status, w.eventID, w.err)
+		}
+	}
+}
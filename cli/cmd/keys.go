@@ -0,0 +1,278 @@
+// / cli/cmd/keys.go — Signing key unlock and passphrase sources
+// / Order: passphrase_file (flag, env or config), BUDDYEVENTS_PASSPHRASE, prompt.
+// / --account picks an HD account (index or address) derived from the seed.
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/wallet"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const passphraseEnv = "BUDDYEVENTS_PASSPHRASE"
+
+// unlockedKey caches the signing key so multi-step commands prompt once.
+var unlockedKey *ecdsa.PrivateKey
+
+// signingKey unlocks the selected HD account or the configured keystore,
+// falling back to a legacy plaintext private_key with a migration warning.
+func signingKey() (*ecdsa.PrivateKey, error) {
+	if unlockedKey != nil {
+		return unlockedKey, nil
+	}
+
+	account, err := selectedAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case account != nil:
+		key, err := deriveAccountKey(account.Index)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(chain.AddressOf(key).Hex(), account.Address) {
+			return nil, fmt.Errorf("seed derives %s at index %d, but config lists %s", chain.AddressOf(key).Hex(), account.Index, account.Address)
+		}
+		unlockedKey = key
+	case cfg.Keystore != "":
+		passphrase, err := readPassphrase("Keystore passphrase: ", false)
+		if err != nil {
+			return nil, err
+		}
+		key, err := wallet.Unlock(cfg.Keystore, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		unlockedKey = key
+	case cfg.PrivateKey != "":
+		if cfg.Source("private_key") != config.SourceEnv {
+			fmt.Fprintln(os.Stderr, "warning: using plaintext private_key from config. Run: buddyevents wallet migrate")
+		}
+		key, err := chain.ParsePrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		unlockedKey = key
+	default:
+		return nil, fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
+	}
+	return unlockedKey, nil
+}
+
+// selectedAccount resolves the account selector (--account, else the
+// profile's account) against the derived accounts. Without a selector it
+// returns the derived account matching wallet_address, if any. A nil account
+// means the keystore key signs.
+func selectedAccount() (*config.Account, error) {
+	selector := cfg.Account
+	for i := range cfg.Accounts {
+		account := &cfg.Accounts[i]
+		if selector == "" && strings.EqualFold(account.Address, cfg.WalletAddress) {
+			return account, nil
+		}
+		if selector != "" && (strings.EqualFold(account.Address, selector) || strconv.FormatUint(uint64(account.Index), 10) == selector) {
+			return account, nil
+		}
+	}
+	if selector == "" || strings.EqualFold(selector, cfg.WalletAddress) {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown account %q. Run: buddyevents wallet accounts", selector)
+}
+
+// walletAddress is the address commands act for: the --account selection,
+// else wallet_address.
+func walletAddress() string {
+	if account, err := selectedAccount(); err == nil && account != nil {
+		return account.Address
+	}
+	return cfg.WalletAddress
+}
+
+// unlockSeed decrypts the configured BIP-39 mnemonic.
+func unlockSeed() (string, error) {
+	if cfg.Seed == "" {
+		return "", fmt.Errorf("no HD seed configured. Run: buddyevents wallet setup --mnemonic")
+	}
+	passphrase, err := readPassphrase("Seed passphrase: ", false)
+	if err != nil {
+		return "", err
+	}
+	return wallet.UnlockMnemonic(cfg.Seed, passphrase)
+}
+
+func deriveAccountKey(index uint32) (*ecdsa.PrivateKey, error) {
+	mnemonic, err := unlockSeed()
+	if err != nil {
+		return nil, err
+	}
+	return wallet.DeriveKey(mnemonic, index)
+}
+
+// readPassphrase resolves the keystore passphrase. When confirm is set and
+// the passphrase is typed interactively, it is asked for twice.
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	// passphrase_file already folds in --passphrase-file and its env var.
+	if file := cfg.PassphraseFile; file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if passphrase, ok := os.LookupEnv(passphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no passphrase available: set %s or pass --passphrase-file", passphraseEnv)
+	}
+	passphrase, err := promptHidden(fd, prompt)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		again, err := promptHidden(fd, "Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+func promptHidden(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(data), nil
+}
+
+// storeKey encrypts key into the keystore directory beside the config file
+// and points the config at it, dropping any plaintext key. A different
+// wallet already configured is only replaced with force.
+func storeKey(key *ecdsa.PrivateKey, force bool) (string, error) {
+	if err := checkWalletReplace(chain.AddressOf(key).Hex(), force); err != nil {
+		return "", err
+	}
+	passphrase, err := readPassphrase("New keystore passphrase: ", true)
+	if err != nil {
+		return "", err
+	}
+	path, err := wallet.Store(filepath.Join(config.Dir(configPath), "keystore"), key, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	cfg.Keystore = path
+	cfg.WalletAddress = chain.AddressOf(key).Hex()
+	cfg.PrivateKey = ""
+	cfg.Seed = ""
+	cfg.Accounts = nil
+	unlockedKey = key
+	if err := saveConfig(); err != nil {
+		return "", fmt.Errorf("failed to save config: %w", err)
+	}
+	return path, nil
+}
+
+// readSecret reads a single secret line, hidden when stdin is a terminal
+// and read from the pipe otherwise.
+func readSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		return promptHidden(fd, prompt)
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readImportPassphrase returns the passphrase protecting a keystore being
+// imported, which is distinct from the one used to re-encrypt it.
+func readImportPassphrase(cmd *cobra.Command) (string, error) {
+	if file, _ := cmd.Flags().GetString("keystore-passphrase-file"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no passphrase for imported keystore: pass --keystore-passphrase-file")
+	}
+	return promptHidden(fd, "Imported keystore passphrase: ")
+}
+
+// storeSeed encrypts mnemonic beside the config, records account 0 and
+// makes it the default wallet. Any wallet already configured is only
+// replaced with force.
+func storeSeed(mnemonic string, force bool) (string, *config.Account, error) {
+	key, err := wallet.DeriveKey(mnemonic, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := checkWalletReplace("", force); err != nil {
+		return "", nil, err
+	}
+	passphrase, err := readPassphrase("New seed passphrase: ", true)
+	if err != nil {
+		return "", nil, err
+	}
+	path, err := wallet.StoreMnemonic(filepath.Join(config.Dir(configPath), "keystore"), mnemonic, passphrase)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cfg.Seed = path
+	cfg.Accounts = []config.Account{{Index: 0, Address: chain.AddressOf(key).Hex()}}
+	cfg.WalletAddress = cfg.Accounts[0].Address
+	cfg.Keystore = ""
+	cfg.PrivateKey = ""
+	unlockedKey = key
+	if err := saveConfig(); err != nil {
+		return "", nil, fmt.Errorf("failed to save config: %w", err)
+	}
+	return path, &cfg.Accounts[0], nil
+}
+
+// checkWalletReplace refuses to unlink the configured wallet unless force
+// is set. A single key may be stored again under the same address (e.g. by
+// wallet migrate); an HD seed is never replaced silently, since that drops
+// every derived account. Replaced keystore and seed files stay on disk.
+func checkWalletReplace(address string, force bool) error {
+	if force {
+		return nil
+	}
+	switch {
+	case cfg.Seed != "":
+		return fmt.Errorf("an HD wallet is already configured (seed %s, derived accounts: %d); pass --force to replace it", cfg.Seed, len(cfg.Accounts))
+	case cfg.Keystore != "" && !strings.EqualFold(cfg.WalletAddress, address):
+		return fmt.Errorf("wallet %s is already configured (keystore %s); pass --force to replace it", cfg.WalletAddress, cfg.Keystore)
+	case cfg.PrivateKey != "" && !strings.EqualFold(cfg.WalletAddress, address):
+		return fmt.Errorf("wallet %s is already configured with a plaintext private_key; back it up with wallet export --private-key, then pass --force to replace it", cfg.WalletAddress)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// / cli/cmd/login.go — Store the bearer token used for API calls
+// / Accepts a Clerk session token or API key and checks it against the API.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Save an API token (Clerk session token or API key)",
+	Long: `Save the bearer token sent with every API request.
+
+The token is read from --token-file, from stdin when piped, or from a hidden
+prompt. Use a Clerk API key for long-running agents; session tokens are
+refreshed automatically while they are still valid.
+
+With --wallet, the CLI instead signs a Sign-In with Ethereum (EIP-4361)
+message with the configured wallet key (see --account) and stores the API
+token the server issues for it. Wallets without an account get one on first
+login. Run it again when the token expires.
+
+The token can also be supplied per process with BUDDYEVENTS_API_TOKEN.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if useWallet, _ := cmd.Flags().GetBool("wallet"); useWallet {
+			return loginWithWallet(cmd)
+		}
+
+		var token string
+		if file, _ := cmd.Flags().GetString("token-file"); file != "" {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read token file: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		} else {
+			var err error
+			if token, err = readSecret("API token: "); err != nil {
+				return err
+			}
+		}
+		if token == "" {
+			return fmt.Errorf("no token given")
+		}
+		if expiry, ok := sdk.TokenExpiry(token); ok && time.Now().After(expiry) {
+			return fmt.Errorf("session token expired at %s; copy a fresh one", expiry.Format(time.RFC3339))
+		}
+
+		client := sdk.NewClient(cfg.APIURL, append(apiOptions(), sdk.WithToken(token))...)
+		session, err := client.WhoAmI(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("token rejected by %s: %w", cfg.APIURL, err)
+		}
+
+		fileCfg.APIToken = token
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return err
+		}
+		warnShadowed("api_token")
+
+		progress("Logged in to %s", cfg.APIURL)
+		return printResult(loginResult{
+			APIURL:        cfg.APIURL,
+			ClerkID:       session.ClerkID,
+			TokenType:     session.TokenType,
+			Role:          session.Role,
+			WalletAddress: session.WalletAddress,
+		})
+	},
+}
+
+// loginResult is who the saved token signs in as.
+type loginResult struct {
+	APIURL        string     `json:"apiUrl"`
+	ClerkID       string     `json:"clerkId"`
+	TokenType     string     `json:"tokenType"` // session_token or api_key
+	Role          string     `json:"role,omitempty"`
+	WalletAddress string     `json:"walletAddress,omitempty"`
+	ExpiresAt     sdk.Millis `json:"expiresAt,omitempty"` // wallet logins only
+}
+
+// loginWithWallet exchanges a signature from the configured key for a token.
+func loginWithWallet(cmd *cobra.Command) error {
+	key, err := signingKey()
+	if err != nil {
+		return err
+	}
+	// The message names the chain the wallet signs for: chain_id, or else
+	// whatever chain the configured RPC serves.
+	chainID := cfg.ChainID
+	if chainID == 0 {
+		client, err := dialChain(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("chain_id is not set and the RPC did not report one: %w", err)
+		}
+		chainID = client.ChainID().Uint64()
+		client.Close()
+	}
+
+	client := sdk.NewClient(cfg.APIURL, apiOptions()...)
+	login, err := client.LoginWithWallet(cmd.Context(), key, chainID)
+	if err != nil {
+		return fmt.Errorf("wallet login failed: %w", err)
+	}
+
+	fileCfg.APIToken = login.Token
+	if err := config.Save(fileCfg, configPath); err != nil {
+		return err
+	}
+	warnShadowed("api_token")
+
+	progress("Logged in to %s", cfg.APIURL)
+	return printResult(loginResult{
+		APIURL:        cfg.APIURL,
+		ClerkID:       login.ClerkID,
+		TokenType:     "api_key",
+		WalletAddress: login.WalletAddress,
+		ExpiresAt:     login.ExpiresAt,
+	})
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the saved API token",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileCfg.APIToken = ""
+		if err := config.Save(fileCfg, configPath); err != nil {
+			return err
+		}
+		progress("Logged out.")
+		warnShadowed("api_token")
+		return nil
+	},
+}
+
+func init() {
+	loginCmd.Flags().String("token-file", "", "read the token from this file instead of stdin")
+	loginCmd.Flags().Bool("wallet", false, "sign in with the wallet key (Sign-In with Ethereum) instead of pasting a token")
+	loginCmd.MarkFlagsMutuallyExclusive("wallet", "token-file")
+}
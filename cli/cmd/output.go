@@ -0,0 +1,458 @@
+// / cli/cmd/output.go — Output formats shared by every command
+// / Tables for humans; JSON, YAML, CSV and Go templates for scripts and agents.
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var outputFormats = []string{"table", "json", "yaml", "csv", "template"}
+
+// outputOptions is the parsed --output, --template and --fields.
+type outputOptions struct {
+	format   string
+	template *template.Template
+	fields   []string
+}
+
+// output is set before every command runs (see parseOutput).
+var output = outputOptions{format: "table"}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringP("output", "o", "table", "output format: "+strings.Join(outputFormats, ", "))
+	flags.String("template", "", "Go text/template applied to each result (implies --output template)")
+	flags.StringSlice("fields", nil, "comma-separated fields (JSON names) to show, in order")
+}
+
+func parseOutput(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	format, _ := flags.GetString("output")
+	text, _ := flags.GetString("template")
+	fields, _ := flags.GetStringSlice("fields")
+
+	if text != "" && !flags.Changed("output") {
+		format = "template"
+	}
+	known := false
+	for _, f := range outputFormats {
+		known = known || f == format
+	}
+	if !known {
+		return usageError{fmt.Errorf("unknown --output %q (want %s)", format, strings.Join(outputFormats, ", "))}
+	}
+
+	output = outputOptions{format: format, fields: fields}
+	switch {
+	case format == "template" && text == "":
+		return usageError{fmt.Errorf("--output template needs --template")}
+	case format != "template" && text != "":
+		return usageError{fmt.Errorf("--template only applies to --output template")}
+	case text != "":
+		tmpl, err := template.New("output").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+		if err != nil {
+			return usageError{fmt.Errorf("invalid --template: %w", err)}
+		}
+		output.template = tmpl
+	}
+	return nil
+}
+
+// progress reports what a command is doing. It goes to stderr so stdout
+// holds only the result.
+func progress(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// printResult writes v, a struct or a slice of structs, in the selected
+// format. columns are the fields (JSON names) a table or CSV shows unless
+// --fields overrides them; without columns it shows every field. JSON and
+// YAML show every field by default.
+func printResult(v any, columns ...string) error {
+	records, isList := recordsOf(v)
+	fields := columns
+	if len(fields) == 0 && len(records) > 0 {
+		fields = fieldNames(records[0])
+	}
+	if len(output.fields) > 0 {
+		fields = output.fields
+		if err := checkFields(records, fields); err != nil {
+			return err
+		}
+	}
+
+	w := os.Stdout
+	switch output.format {
+	case "json", "yaml":
+		data := v
+		if isList && len(records) == 0 {
+			data = []any{} // never null, so the schema is the same every time
+		}
+		if len(output.fields) > 0 {
+			data = project(records, fields, isList)
+		}
+		if output.format == "json" {
+			return writeJSON(w, data)
+		}
+		return writeYAML(w, data)
+	case "csv":
+		return writeCSV(w, records, fields)
+	case "template":
+		for _, r := range records {
+			var buf bytes.Buffer
+			if err := output.template.Execute(&buf, genericValue(r.Interface())); err != nil {
+				return fmt.Errorf("template: %w", err)
+			}
+			if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+			w.Write(buf.Bytes())
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if isList {
+		headers := make([]string, len(fields))
+		for i, f := range fields {
+			headers[i] = columnTitle(f)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+		for _, r := range records {
+			fmt.Fprintln(tw, strings.Join(cells(r, fields), "\t"))
+		}
+	} else if len(records) == 1 {
+		for i, cell := range cells(records[0], fields) {
+			if cell != "" {
+				fmt.Fprintf(tw, "%s\t%s\n", columnTitle(fields[i]), cell)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes a header of fields and one row per record.
+func writeCSV(w io.Writer, records []reflect.Value, fields []string) error {
+	cw := csv.NewWriter(w)
+	cw.Write(fields)
+	for _, r := range records {
+		cw.Write(cells(r, fields))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// recordsOf splits v into its records: the elements of a slice, or v itself.
+func recordsOf(v any) ([]reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+			return nil, false
+		}
+		return []reflect.Value{rv}, false
+	}
+	records := make([]reflect.Value, rv.Len())
+	for i := range records {
+		records[i] = rv.Index(i)
+	}
+	return records, true
+}
+
+// lookupField resolves a dotted path of JSON names, e.g. "event.name".
+func lookupField(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, true // known field, no value
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := structField(v, name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v = field
+		case reflect.Map:
+			field := v.MapIndex(reflect.ValueOf(name))
+			if !field.IsValid() {
+				return reflect.Value{}, false
+			}
+			v = field
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// structField finds the field encoding/json would use for name: fields of
+// the struct itself shadow those of embedded structs.
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	var embedded []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case !f.IsExported():
+		case f.Anonymous && f.Tag.Get("json") == "":
+			embedded = append(embedded, i)
+		case jsonName(f) == name:
+			return v.Field(i), true
+		}
+	}
+	for _, i := range embedded {
+		if field, ok := structField(reflect.Indirect(v.Field(i)), name); ok {
+			return field, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func jsonName(f reflect.StructField) string {
+	tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if tag == "-" {
+		return ""
+	}
+	if tag == "" {
+		return f.Name
+	}
+	return tag
+}
+
+// checkFields rejects --fields names the records do not have.
+func checkFields(records []reflect.Value, fields []string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	for _, f := range fields {
+		if _, ok := lookupField(records[0], f); !ok {
+			return usageError{fmt.Errorf("unknown field %q in --fields (available: %s)", f, strings.Join(fieldNames(records[0]), ", "))}
+		}
+	}
+	return nil
+}
+
+func fieldNames(v reflect.Value) []string {
+	v = reflect.Indirect(v)
+	if v.Kind() == reflect.Map {
+		var names []string
+		for _, k := range v.MapKeys() {
+			names = append(names, k.String())
+		}
+		return names
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			names = append(names, fieldNames(v.Field(i))...)
+		} else if name := jsonName(f); name != "" {
+			names = append(names, name)
+		}
+	}
+	// A field shadowing an embedded one is listed once, where it is embedded.
+	seen := make(map[string]bool, len(names))
+	unique := names[:0]
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+func cells(record reflect.Value, fields []string) []string {
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		if v, ok := lookupField(record, f); ok {
+			row[i] = cellText(v)
+		}
+	}
+	return row
+}
+
+// cellText renders one value for a table or CSV cell.
+func cellText(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if m, ok := v.Interface().(sdk.Millis); ok {
+		if m == 0 {
+			return ""
+		}
+		return showTime(m)
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = cellText(v.Index(i))
+		}
+		return strings.Join(parts, "; ")
+	case reflect.Struct, reflect.Map:
+		return toJSON(v.Interface())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// columnTitle turns a JSON name into a table heading: "ticketsSold" is
+// "TICKETS SOLD" and "_id" is "ID".
+func columnTitle(field string) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range strings.TrimLeft(field, "_") {
+		switch {
+		case r == '.' || r == '_':
+			r = ' '
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			b.WriteRune(' ')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
+}
+
+// orderedRecord is a --fields projection that keeps the requested order
+// when encoded.
+type orderedRecord struct {
+	keys   []string
+	values []any
+}
+
+func (r orderedRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func project(records []reflect.Value, fields []string, isList bool) any {
+	out := make([]orderedRecord, len(records))
+	for i, r := range records {
+		out[i] = orderedRecord{keys: fields, values: make([]any, len(fields))}
+		for j, f := range fields {
+			if v, ok := lookupField(r, f); ok && v.IsValid() {
+				out[i].values[j] = v.Interface()
+			}
+		}
+	}
+	if !isList && len(out) == 1 {
+		return out[0]
+	}
+	return out
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeYAML converts through JSON so keys, their order and omitted fields
+// match --output json.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the flow style and quoting the JSON input left on node.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// genericValue is v as decoded JSON, so templates address fields by their
+// JSON names like --fields does.
+func genericValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v
+	}
+	return generic
+}
+
+func toJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
@@ -2,15 +2,27 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"time"
 
-	"buddyevents/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
 
 	"github.com/spf13/cobra"
 )
 
-var cfg *config.Config
+var (
+	cfg        *config.Config // effective settings: file, then profile, then flags
+	fileCfg    *config.Config // settings as stored on disk
+	configPath string
+	configErr  error // deferred from initConfig, reported before the command runs
+	profileErr error // unknown profile; config commands still run so it can be fixed
+)
+
+const profileEnv = "BUDDYEVENTS_PROFILE"
 
 var rootCmd = &cobra.Command{
 	Use:   "buddyevents",
@@ -22,39 +34,164 @@ Designed to be called by Pi agent via its Bash tool.`,
 }
 
 func Execute() {
+	wrapArgs(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(reportError(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	// Execute reports errors itself (see exit.go); flag errors point at --help.
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(flagError)
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := parseOutput(cmd); err != nil {
+			return err
+		}
+		if configErr != nil {
+			return configErr
+		}
+		// config commands stay usable so a bad profile can be repaired.
+		if profileErr != nil && !underCommand(cmd, configCmd) {
+			return profileErr
+		}
+		// Reject an unknown --account before any command acts for it.
+		_, err := selectedAccount()
+		return err
+	}
 
 	rootCmd.PersistentFlags().String("config", "", "config file (default: ~/.buddyevents/config.json)")
+	rootCmd.PersistentFlags().String("profile", "", "config profile to use (or set "+profileEnv+")")
 	rootCmd.PersistentFlags().String("api-url", "", "API base URL (overrides config)")
 	rootCmd.PersistentFlags().String("convex-url", "", "Convex deployment URL (overrides config)")
+	rootCmd.PersistentFlags().String("account", "", "HD account index or address to act as (see: wallet accounts)")
+	rootCmd.PersistentFlags().String("passphrase-file", "", "file holding the keystore passphrase (or set "+passphraseEnv+")")
+	rootCmd.PersistentFlags().String("timezone", "", "IANA zone for reading and showing times, e.g. Europe/Rome (default: system zone)")
+	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "time limit for each API call (retries included) and each HTTP RPC request; receipts are awaited up to --receipt-timeout (0 = no limit)")
+	rootCmd.PersistentFlags().Uint64("confirmations", 1, "blocks to wait for before a transaction counts as final")
+	rootCmd.PersistentFlags().Duration("receipt-timeout", 2*time.Minute, "how long to wait for a transaction receipt")
+	rootCmd.PersistentFlags().Uint64("gas-limit", 0, "gas limit for sent transactions (default: eth_estimateGas)")
+	rootCmd.PersistentFlags().String("max-fee", "", "max fee per gas in gwei (gas price on legacy chains; default: from base-fee history)")
+	rootCmd.PersistentFlags().String("priority-fee", "", "max priority fee per gas in gwei (default: eth_maxPriorityFeePerGas)")
 
 	rootCmd.AddCommand(eventsCmd)
 	rootCmd.AddCommand(ticketsCmd)
 	rootCmd.AddCommand(walletCmd)
 	rootCmd.AddCommand(agentCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
 }
 
 func initConfig() {
-	configPath, _ := rootCmd.Flags().GetString("config")
+	configPath, _ = rootCmd.Flags().GetString("config")
 	var err error
-	cfg, err = config.Load(configPath)
-	if err != nil {
+	fileCfg, err = config.Load(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
 		// Use defaults if no config exists yet
-		cfg = config.Default()
+		fileCfg = config.Default()
+	} else if err != nil {
+		configErr = fmt.Errorf("failed to load config: %w", err)
+		fileCfg = config.Default()
+	}
+
+	flags := rootCmd.Flags()
+	profile, _ := flags.GetString("profile")
+	profileSource := config.SourceFlag
+	if profile == "" {
+		profile = os.Getenv(profileEnv)
+		profileSource = config.SourceEnv
+	}
+	cfg, err = fileCfg.WithProfile(profile)
+	if err != nil {
+		profileErr = fmt.Errorf("%w. Run: buddyevents config profiles list", err)
+		cfg, _ = fileCfg.WithProfile("")
+		return
+	}
+	if profile != "" {
+		cfg.Override("profile", profile, profileSource)
+	}
+
+	// Precedence: flag > env > profile > file > default.
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		configErr = err
+		return
+	}
+	for flag, key := range map[string]string{
+		"api-url":         "api_url",
+		"convex-url":      "convex_url",
+		"account":         "account",
+		"passphrase-file": "passphrase_file",
+		"timezone":        "timezone",
+	} {
+		if value, _ := flags.GetString(flag); value != "" {
+			if err := cfg.Override(key, value, config.SourceFlag); err != nil && configErr == nil {
+				configErr = err
+			}
+		}
 	}
+}
+
+// apiClient returns an SDK client for the effective API URL, authenticated
+// with the saved token if there is one.
+func apiClient() *sdk.Client {
+	opts := apiOptions()
+	if cfg.APIToken != "" {
+		tokens := sdk.NewRefreshingToken(cfg.APIURL, cfg.APIToken, opts...)
+		if cfg.Source("api_token") == config.SourceFile {
+			tokens.OnRefresh = func(token string) {
+				fileCfg.APIToken = token
+				if err := config.Save(fileCfg, configPath); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: refreshed API token not saved: %v\n", err)
+				}
+			}
+		}
+		opts = append(opts, sdk.WithTokenSource(tokens))
+	}
+	return sdk.NewClient(cfg.APIURL, opts...)
+}
+
+// apiOptions are the client settings shared by every API call: user agent
+// and the --timeout per request.
+func apiOptions() []sdk.Option {
+	return []sdk.Option{
+		sdk.WithUserAgent("buddyevents-cli/" + sdk.Version),
+		sdk.WithTimeout(requestTimeout()),
+	}
+}
+
+func requestTimeout() time.Duration {
+	timeout, _ := rootCmd.Flags().GetDuration("timeout")
+	return timeout
+}
 
-	// CLI flag overrides
-	if apiURL, _ := rootCmd.Flags().GetString("api-url"); apiURL != "" {
-		cfg.APIURL = apiURL
+func underCommand(cmd, parent *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == parent {
+			return true
+		}
 	}
-	if convexURL, _ := rootCmd.Flags().GetString("convex-url"); convexURL != "" {
-		cfg.ConvexURL = convexURL
+	return false
+}
+
+// walletKeys are the settings wallet commands change through cfg.
+var walletKeys = []string{"wallet_address", "keystore", "private_key", "seed"}
+
+// saveConfig persists wallet changes made through cfg to the file selected
+// by --config, without baking profile, env or flag overrides into it.
+func saveConfig() error {
+	for _, key := range walletKeys {
+		value, _ := cfg.Get(key)
+		// An env value stays out of the file unless the command replaced it.
+		if env, ok := os.LookupEnv(config.EnvVar(key)); ok && env == value {
+			continue
+		}
+		if err := fileCfg.Assign(key, value); err != nil {
+			return err
+		}
 	}
+	fileCfg.Accounts = cfg.Accounts
+	return config.Save(fileCfg, configPath)
 }
@@ -1,22 +1,24 @@
 // / cli/cmd/tickets.go — Ticket management commands
-// / buy (on-chain via cast), sell, list tickets
+// / buy (on-chain via contract bindings or x402), sell, list tickets
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/ecdsa"
 	"fmt"
-	"os/exec"
-	"strings"
 
-	"buddyevents/internal/api"
-	x402client "buddyevents/internal/x402"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+	x402client "github.com/OxFrancesco/BuddyEvents/cli/sdk/x402"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
 var ticketsCmd = &cobra.Command{
 	Use:   "tickets",
-	Short: "Manage tickets (buy, sell, list)",
+	Short: "Manage tickets (buy, record, sell, list)",
 }
 
 // ===== tickets list =====
@@ -28,33 +30,48 @@ var ticketsListCmd = &cobra.Command{
 		eventID, _ := cmd.Flags().GetString("event-id")
 
 		if buyer == "" {
-			buyer = cfg.WalletAddress
+			buyer = walletAddress()
 		}
 
-		client := api.NewClient(cfg.APIURL)
+		client := apiClient()
+		ctx := cmd.Context()
 
 		if eventID != "" {
-			tickets, err := client.ListTicketsByEvent(eventID)
+			tickets, err := client.ListTicketsByEvent(ctx, eventID)
 			if err != nil {
 				return fmt.Errorf("failed to list tickets: %w", err)
 			}
-			out, _ := json.MarshalIndent(tickets, "", "  ")
-			fmt.Println(string(out))
-			return nil
+			return printResult(tickets, ticketColumns...)
 		}
 
-		tickets, err := client.ListTicketsByBuyer(buyer)
+		tickets, err := client.ListTicketsByBuyer(ctx, buyer)
 		if err != nil {
 			return fmt.Errorf("failed to list tickets: %w", err)
 		}
-		out, _ := json.MarshalIndent(tickets, "", "  ")
-		fmt.Println(string(out))
-		return nil
+		return printResult(tickets, ticketColumns...)
 	},
 }
 
+// ticketColumns are the table columns for tickets.
+var ticketColumns = []string{"_id", "eventId", "tokenId", "status", "purchasePrice", "buyerAddress", "checkedInAt"}
+
+// ticketPurchase is the result of buying or recording a ticket.
+type ticketPurchase struct {
+	TicketID        string `json:"ticketId"`
+	EventID         string `json:"eventId"`
+	TokenID         string `json:"tokenId"` // empty for x402 purchases
+	Price           string `json:"price"`   // USDC; empty for x402 purchases
+	Buyer           string `json:"buyer"`
+	TxHash          string `json:"txHash"` // buyTicket or x402 settlement
+	Block           uint64 `json:"block"`  // 0 for x402 purchases
+	QRCode          string `json:"qrCode"`
+	AlreadyRecorded bool   `json:"alreadyRecorded"`
+}
+
+var purchaseColumns = []string{"ticketId", "eventId", "tokenId", "price", "buyer", "txHash", "block", "qrCode"}
+
 // ===== tickets buy =====
-// Uses Foundry's `cast` to interact with the smart contract on Monad
+// Signs and sends contract calls in-process via the chain bindings
 var ticketsBuyCmd = &cobra.Command{
 	Use:   "buy",
 	Short: "Buy a ticket (on-chain via USDC on Monad)",
@@ -66,102 +83,175 @@ var ticketsBuyCmd = &cobra.Command{
 			return fmt.Errorf("provide --on-chain-id (direct contract call) or --event-id (x402 API purchase)")
 		}
 
-		if cfg.PrivateKey == "" {
-			return fmt.Errorf("no private key configured. Run: buddyevents wallet setup")
+		key, err := signingKey()
+		if err != nil {
+			return err
 		}
 
-		contractAddr := cfg.ContractAddress
-		rpcURL := cfg.MonadRPC
-
 		if onChainEventID != "" {
-			// Direct on-chain purchase via cast
-			fmt.Println("Buying ticket on-chain via Monad...")
+			ctx := cmd.Context()
+			eventID, err := parseUint256("on-chain event id", onChainEventID)
+			if err != nil {
+				return err
+			}
 
-			// Step 1: Get event price
-			priceOut, err := runCast("call", "--rpc-url", rpcURL,
-				contractAddr, "getEvent(uint256)(string,uint256,uint256,uint256,address,bool)", onChainEventID)
+			client, err := dialChain(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to get event: %w", err)
+				return err
 			}
-			fmt.Printf("Event info: %s\n", priceOut)
-
-			// Step 2: Approve USDC
-			usdcAddr := cfg.USDCAddress
-			fmt.Println("Approving USDC...")
-			approveTx, err := runCast("send", "--rpc-url", rpcURL,
-				"--private-key", cfg.PrivateKey,
-				usdcAddr, "approve(address,uint256)", contractAddr, "1000000000") // Approve max for simplicity
+			defer client.Close()
+
+			market, err := marketFor(client)
 			if err != nil {
-				return fmt.Errorf("USDC approve failed: %w", err)
+				return err
 			}
-			fmt.Printf("Approve tx: %s\n", approveTx)
 
-			// Step 3: Buy ticket
-			fmt.Println("Buying ticket...")
-			buyTx, err := runCast("send", "--rpc-url", rpcURL,
-				"--private-key", cfg.PrivateKey,
-				contractAddr, "buyTicket(uint256)", onChainEventID)
+			// Approve only what the purchase needs, then buy and wait
+			exactApproval, _ := cmd.Flags().GetBool("exact-approval")
+			opts, err := txOptions(chain.WithExactApproval(exactApproval))
 			if err != nil {
-				return fmt.Errorf("buy ticket failed: %w", err)
+				return err
 			}
-			fmt.Printf("Buy tx: %s\n", buyTx)
-			fmt.Println("Ticket purchased successfully on Monad!")
-		}
+			progress("Buying ticket on-chain via Monad...")
+			purchase, err := market.BuyTicket(ctx, key, eventID, opts...)
+			if err != nil {
+				return err
+			}
+			progress("Ticket %s minted in block %s", purchase.Ticket.TokenId, purchase.Receipt.BlockNumber)
 
-		// If event ID provided, purchase through x402-protected API.
-		if convexEventID != "" {
-			if cfg.WalletAddress == "" {
-				return fmt.Errorf("no wallet address configured. Run: buddyevents wallet setup")
+			// Issue the off-chain ticket + QR used for check-in
+			txHash := purchase.Receipt.TxHash
+			result, err := recordPurchase(ctx, key, purchase.Receipt, purchase.Ticket, convexEventID)
+			if err != nil {
+				return fmt.Errorf("ticket minted but not recorded off-chain: %w\nRetry with: buddyevents tickets record --tx-hash %s",
+					err, txHash.Hex())
 			}
+			return printResult(result, purchaseColumns...)
+		}
 
-			fmt.Println("Buying ticket through x402 payment flow...")
-			result, err := x402client.BuyTicket(
-				cfg.APIURL,
-				convexEventID,
-				cfg.WalletAddress,
-				"",
-				cfg.PrivateKey,
-			)
+		// If only an event ID is provided, purchase through x402-protected API.
+		if convexEventID != "" {
+			progress("Buying ticket through x402 payment flow...")
+			result, err := x402client.BuyTicket(cmd.Context(), cfg.APIURL, convexEventID, key, x402client.WithTimeout(requestTimeout()))
 			if err != nil {
 				return fmt.Errorf("x402 purchase failed: %w", err)
-			} else {
-				fmt.Printf("Ticket purchased!\n")
-				fmt.Printf("Ticket ID: %s\n", result.TicketID)
-				if result.QRCode != "" {
-					fmt.Printf("Ticket QR Code: %s\n", result.QRCode)
-				}
-				fmt.Printf("Settlement Tx: %s\n", result.TxHash)
 			}
+			return printResult(ticketPurchase{
+				TicketID: result.TicketID,
+				EventID:  result.EventID,
+				Buyer:    result.Buyer,
+				TxHash:   result.TxHash,
+				QRCode:   result.QRCode,
+			}, purchaseColumns...)
 		}
 
 		return nil
 	},
 }
 
+// ===== tickets record =====
+var ticketsRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record a confirmed on-chain purchase to get its ticket ID and QR code",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		txHashFlag, _ := cmd.Flags().GetString("tx-hash")
+		convexEventID, _ := cmd.Flags().GetString("event-id")
+
+		if !isTxHash(txHashFlag) {
+			return fmt.Errorf("invalid --tx-hash: %q", txHashFlag)
+		}
+		key, err := signingKey()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		client, err := dialChain(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		market, err := marketFor(client)
+		if err != nil {
+			return err
+		}
+
+		receipt, err := waitForReceipt(ctx, client, common.HexToHash(txHashFlag))
+		if err != nil {
+			return err
+		}
+		purchased, err := market.Contract().TicketPurchasedLog(receipt)
+		if err != nil {
+			return err
+		}
+		result, err := recordPurchase(ctx, key, receipt, purchased, convexEventID)
+		if err != nil {
+			return err
+		}
+		return printResult(result, purchaseColumns...)
+	},
+}
+
 // ===== tickets sell =====
 var ticketsSellCmd = &cobra.Command{
 	Use:   "sell",
 	Short: "List a ticket for resale on the marketplace",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		tokenID, _ := cmd.Flags().GetString("token-id")
-		price, _ := cmd.Flags().GetString("price")
+		tokenIDFlag, _ := cmd.Flags().GetString("token-id")
+		priceFlag, _ := cmd.Flags().GetString("price")
 
-		if cfg.PrivateKey == "" {
-			return fmt.Errorf("no private key configured")
+		ctx := cmd.Context()
+		tokenID, err := parseUint256("token id", tokenIDFlag)
+		if err != nil {
+			return err
+		}
+		price, err := parseUint256("price", priceFlag)
+		if err != nil {
+			return err
+		}
+		key, err := signingKey()
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("Listing ticket #%s for %s USDC...\n", tokenID, price)
-		tx, err := runCast("send", "--rpc-url", cfg.MonadRPC,
-			"--private-key", cfg.PrivateKey,
-			cfg.ContractAddress, "listTicket(uint256,uint256)", tokenID, price)
+		client, err := dialChain(ctx)
 		if err != nil {
-			return fmt.Errorf("list ticket failed: %w", err)
+			return err
 		}
-		fmt.Printf("Listed! Tx: %s\n", tx)
-		return nil
+		defer client.Close()
+
+		market, err := marketFor(client)
+		if err != nil {
+			return err
+		}
+		opts, err := txOptions()
+		if err != nil {
+			return err
+		}
+
+		progress("Listing ticket #%s for %s USDC...", tokenID, formatUSDC(price))
+		listed, receipt, err := market.ListTicket(ctx, key, tokenID, price, opts...)
+		if err != nil {
+			return err
+		}
+		return printResult(ticketListing{
+			TokenID: listed.TokenId.String(),
+			Price:   formatUSDC(listed.Price),
+			TxHash:  receipt.TxHash.Hex(),
+			Block:   receipt.BlockNumber.Uint64(),
+		})
 	},
 }
 
+// ticketListing is the result of tickets sell.
+type ticketListing struct {
+	TokenID string `json:"tokenId"`
+	Price   string `json:"price"` // USDC
+	TxHash  string `json:"txHash"`
+	Block   uint64 `json:"block"`
+}
+
 func init() {
 	// tickets list
 	ticketsListCmd.Flags().String("buyer", "", "Filter by buyer address (defaults to config wallet)")
@@ -169,7 +259,13 @@ func init() {
 
 	// tickets buy
 	ticketsBuyCmd.Flags().String("on-chain-id", "", "On-chain event ID (for direct contract call)")
-	ticketsBuyCmd.Flags().String("event-id", "", "Convex event ID (for API purchase)")
+	ticketsBuyCmd.Flags().String("event-id", "", "Convex event ID (x402 API purchase, or the Convex mapping for --on-chain-id)")
+	ticketsBuyCmd.Flags().Bool("exact-approval", false, "Reset the USDC allowance to exactly the ticket price, even if it already covers it")
+
+	// tickets record
+	ticketsRecordCmd.Flags().String("tx-hash", "", "buyTicket transaction hash")
+	ticketsRecordCmd.Flags().String("event-id", "", "Convex event ID (defaults to the event linked to the on-chain ID)")
+	_ = ticketsRecordCmd.MarkFlagRequired("tx-hash")
 
 	// tickets sell
 	ticketsSellCmd.Flags().String("token-id", "", "NFT token ID to sell")
@@ -180,15 +276,33 @@ func init() {
 	ticketsCmd.AddCommand(ticketsListCmd)
 	ticketsCmd.AddCommand(ticketsBuyCmd)
 	ticketsCmd.AddCommand(ticketsSellCmd)
+	ticketsCmd.AddCommand(ticketsRecordCmd)
 }
 
-// runCast executes a `cast` command (Foundry) and returns stdout
-func runCast(args ...string) (string, error) {
-	cmd := exec.Command("cast", args...)
-	out, err := cmd.CombinedOutput()
-	result := strings.TrimSpace(string(out))
+// recordPurchase submits a confirmed buyTicket purchase to the API, signed by
+// the buyer, and returns the resulting off-chain ticket.
+func recordPurchase(ctx context.Context, key *ecdsa.PrivateKey, receipt *types.Receipt, purchased *chain.TicketPurchased, convexEventID string) (*ticketPurchase, error) {
+	result, err := apiClient().ClaimPurchase(ctx, key, receipt.TxHash, purchased, convexEventID)
 	if err != nil {
-		return result, fmt.Errorf("%s: %w", result, err)
+		return nil, err
 	}
-	return result, nil
+	if result.AlreadyRecorded {
+		progress("Purchase was already recorded; issued a fresh QR code")
+	}
+	return &ticketPurchase{
+		TicketID:        result.TicketID,
+		EventID:         result.EventID,
+		TokenID:         purchased.TokenId.String(),
+		Price:           formatUSDC(purchased.Price),
+		Buyer:           purchased.Buyer.Hex(),
+		TxHash:          receipt.TxHash.Hex(),
+		Block:           receipt.BlockNumber.Uint64(),
+		QRCode:          result.QRCode,
+		AlreadyRecorded: result.AlreadyRecorded,
+	}, nil
+}
+
+func isTxHash(s string) bool {
+	b, err := hexutil.Decode(s)
+	return err == nil && len(b) == common.HashLength
 }
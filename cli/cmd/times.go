@@ -0,0 +1,91 @@
+// / cli/cmd/times.go — Reading and showing event times
+// / Accepts RFC 3339, "2026-11-03 18:00 Europe/Rome" or unix ms; shows times in the user's zone.
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+// localLayouts are the zone-less forms parseTime reads in a location.
+var localLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+const timeFormatHint = `want RFC 3339 (2026-11-03T18:00:00+01:00), "2026-11-03 18:00", "2026-11-03 18:00 Europe/Rome", "tomorrow 18:00" or unix milliseconds`
+
+// userZone is the zone times are read and shown in: --timezone, the
+// timezone config key, or the system zone.
+func userZone() *time.Location {
+	if cfg != nil && cfg.Timezone != "" {
+		if loc, err := time.LoadLocation(cfg.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// parseTime reads a time flag. Values without an offset are in the zone
+// named at the end of the value, or else in loc.
+func parseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	parts := strings.Fields(value)
+	if n := len(parts); n > 1 {
+		if zone, err := time.LoadLocation(parts[n-1]); err == nil && parts[n-1] != "Local" {
+			loc, parts = zone, parts[:n-1]
+		}
+	}
+	if len(parts) > 0 {
+		now := time.Now().In(loc)
+		switch strings.ToLower(parts[0]) {
+		case "today":
+			parts[0] = now.Format("2006-01-02")
+		case "tomorrow":
+			parts[0] = now.AddDate(0, 0, 1).Format("2006-01-02")
+		}
+	}
+	rest := strings.Join(parts, " ")
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, rest, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot read %q as a time: %s", value, timeFormatHint)
+}
+
+// timeFlag parses the time flag name of cmd.
+func timeFlag(cmd *cobra.Command, name string, loc *time.Location) (time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	t, err := parseTime(value, loc)
+	if err != nil {
+		return time.Time{}, usageError{fmt.Errorf("invalid --%s: %w", name, err)}
+	}
+	return t, nil
+}
+
+// showTime renders a Convex timestamp in the user's zone for tables, and as
+// RFC 3339 with the zone's offset for CSV.
+func showTime(m sdk.Millis) string {
+	t := m.Time().In(userZone())
+	if output.format == "csv" {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"unix ms", "1793725200000", time.UnixMilli(1793725200000), false},
+		{"rfc 3339", "2026-11-03T18:00:00+01:00", time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC), false},
+		{"rfc 3339 utc", "2026-11-03T17:00:00Z", time.Date(2026, 11, 3, 17, 0, 0, 0, time.UTC), false},
+		{"local minutes", "2026-11-03 18:00", time.Date(2026, 11, 3, 18, 0, 0, 0, rome), false},
+		{"local seconds with T", "2026-11-03T18:00:30", time.Date(2026, 11, 3, 18, 0, 30, 0, rome), false},
+		{"date only", "2026-11-03", time.Date(2026, 11, 3, 0, 0, 0, 0, rome), false},
+		{"spaces trimmed", "  2026-11-03 18:00  ", time.Date(2026, 11, 3, 18, 0, 0, 0, rome), false},
+		{"named zone", "2026-11-03 18:00 America/New_York", time.Date(2026, 11, 3, 18, 0, 0, 0, newYork), false},
+		{"named zone on a date", "2026-11-03 UTC", time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC), false},
+		// 02:00-03:00 does not exist in Rome on 2026-03-29: the clock is
+		// read with the offset before the jump, so 02:30 is 03:30 CEST.
+		{"dst gap", "2026-03-29 02:30", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), false},
+		{"dst gap in named zone", "2026-03-29 02:30 Europe/Rome", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), false},
+		// 02:00-03:00 happens twice in Rome on 2026-10-25: the second,
+		// CET one is taken.
+		{"dst overlap", "2026-10-25 02:30", time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), false},
+		{"day after the overlap", "2026-10-26 02:30", time.Date(2026, 10, 26, 1, 30, 0, 0, time.UTC), false},
+		{"day before the overlap", "2026-10-24 02:30", time.Date(2026, 10, 24, 0, 30, 0, 0, time.UTC), false},
+		{"Local is not a zone name", "2026-11-03 18:00 Local", time.Time{}, true},
+		{"unknown zone", "2026-11-03 18:00 Mars/Olympus", time.Time{}, true},
+		{"garbage", "next blue moon", time.Time{}, true},
+		{"empty", "", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.value, rome)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "cannot read") {
+					t.Errorf("parseTime(%q) = %s, %v; want an error", tt.value, got, err)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("parseTime(%q) = %s, %v; want %s", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeRelative(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	now := time.Now().In(rome)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"today", time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rome)},
+		{"Today 18:00", time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, rome)},
+		{"tomorrow 09:30", time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 30, 0, 0, rome)},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(tt.value, rome)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseTime(%q) = %s, %v; want %s", tt.value, got, err, tt.want)
+		}
+	}
+}
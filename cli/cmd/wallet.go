@@ -1,20 +1,28 @@
 // / cli/cmd/wallet.go — Wallet management commands
-// / setup new wallet, check balance, fund from faucet
+// / setup/import/export encrypted and HD wallets, check balance, fund from faucet
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"crypto/ecdsa"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"math/big"
 	"net/http"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 
-	"buddyevents/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/wallet"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -24,96 +32,433 @@ import (
 
 var walletCmd = &cobra.Command{
 	Use:   "wallet",
-	Short: "Wallet management (setup, balance, fund)",
+	Short: "Wallet management (setup, import, export, derive, accounts, balance, fund, send, allowance)",
 }
 
 // ===== wallet setup =====
 var walletSetupCmd = &cobra.Command{
 	Use:   "setup",
-	Short: "Generate a new wallet for this agent",
+	Short: "Generate a new wallet for this agent (encrypted keystore or HD seed)",
+	Long: `Generate a new wallet. By default a single random key is written to an
+encrypted keystore. With --mnemonic, a BIP-39 seed is created instead (or
+read from stdin with --import) and account 0 on m/44'/60'/0'/0/0 becomes the
+default wallet; add more accounts with 'wallet derive'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		useMnemonic, _ := cmd.Flags().GetBool("mnemonic")
+		importMnemonic, _ := cmd.Flags().GetBool("import")
+		mnemonicOut, _ := cmd.Flags().GetString("mnemonic-out")
+		force, _ := cmd.Flags().GetBool("force")
+		if (importMnemonic || mnemonicOut != "") && !useMnemonic {
+			return fmt.Errorf("--import and --mnemonic-out require --mnemonic")
+		}
+		if useMnemonic {
+			return setupHDWallet(importMnemonic, mnemonicOut, force)
+		}
+
 		privateKey, err := crypto.GenerateKey()
 		if err != nil {
 			return fmt.Errorf("failed to generate key: %w", err)
 		}
 
-		privateKeyBytes := crypto.FromECDSA(privateKey)
-		publicKey := privateKey.Public().(*ecdsa.PublicKey)
-		address := crypto.PubkeyToAddress(*publicKey)
+		path, err := storeKey(privateKey, force)
+		if err != nil {
+			return err
+		}
 
-		cfg.PrivateKey = hexutil.Encode(privateKeyBytes)
-		cfg.WalletAddress = address.Hex()
+		progress("Wallet created! The private key is encrypted with your passphrase; back up the keystore file.")
+		printFundingHint()
+		return printResult(walletInfo{Address: cfg.WalletAddress, Keystore: path})
+	},
+}
 
-		if err := config.Save(cfg, ""); err != nil {
+func setupHDWallet(importMnemonic bool, mnemonicOut string, force bool) error {
+	var (
+		mnemonic string
+		err      error
+	)
+	if importMnemonic {
+		mnemonic, err = readSecret("Mnemonic: ")
+		if err == nil {
+			mnemonic, err = wallet.NormalizeMnemonic(mnemonic)
+		}
+	} else {
+		mnemonic, err = wallet.NewMnemonic()
+	}
+	if err != nil {
+		return err
+	}
+
+	path, account, err := storeSeed(mnemonic, force)
+	if err != nil {
+		return err
+	}
+
+	progress("HD wallet created!")
+	if !importMnemonic {
+		if mnemonicOut != "" {
+			if err := os.WriteFile(mnemonicOut, []byte(mnemonic+"\n"), 0600); err != nil {
+				return fmt.Errorf("failed to write mnemonic: %w", err)
+			}
+			progress("Mnemonic written to %s; move it somewhere safe.", mnemonicOut)
+		} else {
+			// stderr keeps the phrase out of captured command output.
+			progress("\nBackup mnemonic (shown once, anyone with it controls every account):\n%s", mnemonic)
+		}
+	}
+	printFundingHint()
+	return printResult(accountInfo(*account, path))
+}
+
+// walletInfo is the result of the commands that create or select a wallet.
+type walletInfo struct {
+	Address  string  `json:"address"`
+	Index    *uint32 `json:"index,omitempty"` // HD account index
+	Path     string  `json:"path,omitempty"`  // HD derivation path
+	Keystore string  `json:"keystore,omitempty"`
+	Seed     string  `json:"seed,omitempty"`
+}
+
+func accountInfo(account config.Account, seed string) walletInfo {
+	index := account.Index
+	return walletInfo{
+		Address: account.Address,
+		Index:   &index,
+		Path:    wallet.DerivationPath(account.Index).String(),
+		Seed:    seed,
+	}
+}
+
+func printFundingHint() {
+	progress("\nNext: Fund your wallet with testnet MON and USDC:")
+	progress("  MON:  https://faucet.monad.xyz")
+	progress("  USDC: https://faucet.circle.com (select Monad Testnet)")
+}
+
+// ===== wallet derive =====
+var walletDeriveCmd = &cobra.Command{
+	Use:   "derive",
+	Short: "Derive another account from the HD seed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		index, _ := cmd.Flags().GetInt("index")
+		if !cmd.Flags().Changed("index") {
+			index = nextAccountIndex()
+		}
+		if index < 0 || index >= 0x80000000 {
+			return fmt.Errorf("--index must be between 0 and %d", 0x7fffffff)
+		}
+
+		key, err := deriveAccountKey(uint32(index))
+		if err != nil {
+			return err
+		}
+		account := config.Account{Index: uint32(index), Address: chain.AddressOf(key).Hex()}
+
+		for _, existing := range cfg.Accounts {
+			if existing.Index == account.Index {
+				progress("Account %d already derived", existing.Index)
+				return printResult(accountInfo(existing, ""))
+			}
+		}
+		cfg.Accounts = append(cfg.Accounts, account)
+		sort.Slice(cfg.Accounts, func(i, j int) bool { return cfg.Accounts[i].Index < cfg.Accounts[j].Index })
+		if err := saveConfig(); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Printf("Wallet created!\n")
-		fmt.Printf("Address:     %s\n", address.Hex())
-		fmt.Printf("Private Key: %s\n", hexutil.Encode(privateKeyBytes))
-		fmt.Printf("\nSaved to ~/.buddyevents/config.json\n")
-		fmt.Printf("\nNext: Fund your wallet with testnet MON and USDC:\n")
-		fmt.Printf("  MON:  https://faucet.monad.xyz\n")
-		fmt.Printf("  USDC: https://faucet.circle.com (select Monad Testnet)\n")
+		progress("Use it with: buddyevents --account %d <command>", account.Index)
+		return printResult(accountInfo(account, ""))
+	},
+}
+
+func nextAccountIndex() int {
+	next := 0
+	for _, account := range cfg.Accounts {
+		if int(account.Index) >= next {
+			next = int(account.Index) + 1
+		}
+	}
+	return next
+}
+
+// ===== wallet accounts =====
+var walletAccountsCmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "List wallet accounts with MON and USDC balances",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accounts := cfg.Accounts
+		if len(accounts) == 0 {
+			if cfg.WalletAddress == "" {
+				return fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
+			}
+			accounts = []config.Account{{Address: cfg.WalletAddress}}
+		}
+
+		// Balances are best-effort so the listing still works offline.
+		ctx := cmd.Context()
+		client, err := dialChain(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: balances unavailable: %v\n", err)
+		} else {
+			defer client.Close()
+		}
+		var usdc *chain.ERC20
+		if client != nil {
+			usdc, _ = usdcContract(client)
+		}
+
+		active := walletAddress()
+		rows := make([]accountBalance, len(accounts))
+		for i, account := range accounts {
+			row := accountBalance{
+				Active:  activeMark(strings.EqualFold(account.Address, active)),
+				Path:    "keystore",
+				Address: account.Address,
+			}
+			if len(cfg.Accounts) > 0 {
+				index := account.Index
+				row.Index = &index
+				row.Path = wallet.DerivationPath(account.Index).String()
+			}
+
+			addr := common.HexToAddress(account.Address)
+			if client != nil {
+				if wei, err := client.Backend().BalanceAt(ctx, addr, nil); err == nil {
+					row.MON = formatMON(wei)
+				}
+			}
+			if usdc != nil {
+				if units, err := usdc.BalanceOf(&bind.CallOpts{Context: ctx}, addr); err == nil {
+					row.USDC = formatUSDC(units)
+				}
+			}
+			rows[i] = row
+		}
+		return printResult(rows)
+	},
+}
+
+// accountBalance is a row of wallet accounts. MON and USDC are empty when
+// the balance could not be read.
+type accountBalance struct {
+	Active  activeMark `json:"active"`
+	Index   *uint32    `json:"index"` // null for a single-key wallet
+	Path    string     `json:"path"`
+	Address string     `json:"address"`
+	MON     string     `json:"mon"`
+	USDC    string     `json:"usdc"`
+}
+
+// activeMark shows the selected account as "*" in tables.
+type activeMark bool
+
+func (a activeMark) String() string {
+	if a {
+		return "*"
+	}
+	return ""
+}
+
+// ===== wallet import =====
+var walletImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a private key or keystore file into an encrypted keystore",
+	Long: `Import an existing wallet. By default the hex private key is read from
+stdin (hidden when interactive) so it never appears in shell history.
+With --keystore, an existing keystore file is unlocked with
+--keystore-passphrase-file (or a prompt) and re-encrypted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keystorePath, _ := cmd.Flags().GetString("keystore")
+		keyFile, _ := cmd.Flags().GetString("key-file")
+		force, _ := cmd.Flags().GetBool("force")
+
+		var (
+			key *ecdsa.PrivateKey
+			err error
+		)
+		switch {
+		case keystorePath != "":
+			data, readErr := os.ReadFile(keystorePath)
+			if readErr != nil {
+				return fmt.Errorf("failed to read keystore: %w", readErr)
+			}
+			passphrase, passErr := readImportPassphrase(cmd)
+			if passErr != nil {
+				return passErr
+			}
+			key, err = wallet.Decrypt(data, passphrase)
+		case keyFile != "":
+			data, readErr := os.ReadFile(keyFile)
+			if readErr != nil {
+				return fmt.Errorf("failed to read key file: %w", readErr)
+			}
+			key, err = chain.ParsePrivateKey(string(data))
+		default:
+			hexKey, readErr := readSecret("Private key (hex): ")
+			if readErr != nil {
+				return readErr
+			}
+			key, err = chain.ParsePrivateKey(hexKey)
+		}
+		if err != nil {
+			return err
+		}
+
+		path, err := storeKey(key, force)
+		if err != nil {
+			return err
+		}
+		progress("Wallet imported!")
+		return printResult(walletInfo{Address: cfg.WalletAddress, Keystore: path})
+	},
+}
+
+// ===== wallet export =====
+var walletExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the wallet as keystore JSON, a raw hex key, or the HD mnemonic",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, _ := cmd.Flags().GetBool("private-key")
+		showMnemonic, _ := cmd.Flags().GetBool("mnemonic")
+		// The secret is printed exactly as it would be stored, never wrapped.
+		if output.format != "table" {
+			return usageError{fmt.Errorf("wallet export prints the secret as is; --output %s does not apply", output.format)}
+		}
+
+		if showMnemonic {
+			mnemonic, err := unlockSeed()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "warning: anyone with this mnemonic controls every derived account")
+			fmt.Println(mnemonic)
+			return nil
+		}
+
+		if !raw {
+			if cfg.Keystore == "" {
+				if cfg.Seed != "" {
+					return fmt.Errorf("HD wallets have no keystore file; use --private-key or --mnemonic")
+				}
+				return fmt.Errorf("no keystore configured. Run: buddyevents wallet migrate")
+			}
+			data, err := os.ReadFile(cfg.Keystore)
+			if err != nil {
+				return fmt.Errorf("failed to read keystore: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		key, err := signingKey()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "warning: anyone with this key controls the wallet")
+		fmt.Println(hexutil.Encode(crypto.FromECDSA(key)))
 		return nil
 	},
 }
 
+// ===== wallet migrate =====
+var walletMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move a plaintext private_key from config into an encrypted keystore",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.PrivateKey == "" {
+			if cfg.Keystore != "" {
+				progress("Already using a keystore")
+				return printResult(walletInfo{Address: cfg.WalletAddress, Keystore: cfg.Keystore})
+			}
+			return fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
+		}
+
+		key, err := chain.ParsePrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return err
+		}
+		if cfg.WalletAddress != "" && !strings.EqualFold(cfg.WalletAddress, chain.AddressOf(key).Hex()) {
+			return fmt.Errorf("private_key does not match wallet_address %s; fix the config before migrating", cfg.WalletAddress)
+		}
+
+		path, err := storeKey(key, false)
+		if err != nil {
+			return err
+		}
+		progress("Migrated; the plaintext private_key has been removed from config.")
+		return printResult(walletInfo{Address: cfg.WalletAddress, Keystore: path})
+	},
+}
+
 // ===== wallet balance =====
 var walletBalanceCmd = &cobra.Command{
 	Use:   "balance",
 	Short: "Check wallet balances (MON + USDC)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		addr := cfg.WalletAddress
+		addr := walletAddress()
 		if addr == "" {
 			return fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
 		}
 
-		fmt.Printf("Wallet: %s\n\n", addr)
+		result := walletBalance{Address: addr}
 
 		// MON balance via JSON-RPC
-		monBal, err := jsonRPCCall(cfg.MonadRPC, "eth_getBalance", []interface{}{addr, "latest"})
-		if err != nil {
-			fmt.Printf("MON:  error: %v\n", err)
+		monBal, monErr := jsonRPCCall(cmd.Context(), cfg.MonadRPC, "eth_getBalance", []interface{}{addr, "latest"})
+		if monErr != nil {
+			progress("warning: MON balance unavailable: %v", monErr)
 		} else {
 			wei := new(big.Int)
 			wei.SetString(strings.TrimPrefix(monBal, "0x"), 16)
 			eth := new(big.Float).Quo(new(big.Float).SetInt(wei), new(big.Float).SetInt(big.NewInt(1e18)))
-			fmt.Printf("MON:  %s\n", eth.Text('f', 6))
+			result.MON = eth.Text('f', 6)
 		}
 
 		// USDC balance via ERC20 balanceOf call
 		callData := "0x70a08231000000000000000000000000" + strings.TrimPrefix(addr, "0x")
-		usdcBal, err := jsonRPCCall(cfg.MonadRPC, "eth_call",
+		usdcBal, usdcErr := jsonRPCCall(cmd.Context(), cfg.MonadRPC, "eth_call",
 			[]interface{}{map[string]string{"to": cfg.USDCAddress, "data": callData}, "latest"})
-		if err != nil {
-			fmt.Printf("USDC: error: %v\n", err)
+		if usdcErr != nil {
+			progress("warning: USDC balance unavailable: %v", usdcErr)
 		} else {
 			units := new(big.Int)
 			units.SetString(strings.TrimPrefix(usdcBal, "0x"), 16)
 			usdc := new(big.Float).Quo(new(big.Float).SetInt(units), new(big.Float).SetInt(big.NewInt(1e6)))
-			fmt.Printf("USDC: %s\n", usdc.Text('f', 6))
+			result.USDC = usdc.Text('f', 6)
 		}
 
-		return nil
+		if monErr != nil && usdcErr != nil {
+			return fmt.Errorf("failed to read balances: %w", monErr)
+		}
+		return printResult(result)
 	},
 }
 
+// walletBalance is the result of wallet balance. A balance that could not
+// be read is empty.
+type walletBalance struct {
+	Address string `json:"address"`
+	MON     string `json:"mon"`
+	USDC    string `json:"usdc"`
+}
+
 // ===== wallet fund =====
 var walletFundCmd = &cobra.Command{
 	Use:   "fund",
 	Short: "Request testnet MON from faucet",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		addr := cfg.WalletAddress
+		addr := walletAddress()
 		if addr == "" {
 			return fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
 		}
 
-		fmt.Printf("Requesting testnet MON for %s...\n", addr)
+		progress("Requesting testnet MON for %s...", addr)
 
 		body := fmt.Sprintf(`{"chainId": 10143, "address": "%s"}`, addr)
-		resp, err := http.Post("https://agents.devnads.com/v1/faucet",
-			"application/json", strings.NewReader(body))
+		req, err := http.NewRequestWithContext(cmd.Context(), http.MethodPost, "https://agents.devnads.com/v1/faucet", strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := (&http.Client{Timeout: requestTimeout()}).Do(req)
 		if err != nil {
 			return fmt.Errorf("faucet request failed: %w", err)
 		}
@@ -124,14 +469,21 @@ var walletFundCmd = &cobra.Command{
 			return fmt.Errorf("faucet error (%d): %s", resp.StatusCode, string(respBody))
 		}
 
-		var result map[string]interface{}
+		var result struct {
+			TxHash string `json:"txHash"`
+		}
 		json.Unmarshal(respBody, &result)
-		fmt.Printf("Funded! Tx: %v\n", result["txHash"])
-		fmt.Printf("\nFor USDC, visit: https://faucet.circle.com (select Monad Testnet)\n")
-		return nil
+		progress("Funded! For USDC, visit: https://faucet.circle.com (select Monad Testnet)")
+		return printResult(faucetFunding{Address: addr, TxHash: result.TxHash})
 	},
 }
 
+// faucetFunding is the result of wallet fund.
+type faucetFunding struct {
+	Address string `json:"address"`
+	TxHash  string `json:"txHash"`
+}
+
 // ===== wallet send =====
 var (
 	sendTo     string
@@ -143,103 +495,191 @@ var walletSendCmd = &cobra.Command{
 	Use:   "send",
 	Short: "Send MON or USDC from configured wallet",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if cfg.WalletAddress == "" || cfg.PrivateKey == "" {
-			return fmt.Errorf("no wallet configured. Run: buddyevents wallet setup")
-		}
 		if !common.IsHexAddress(sendTo) {
 			return fmt.Errorf("invalid --to address: %s", sendTo)
 		}
 		if sendAmount <= 0 {
 			return fmt.Errorf("amount must be > 0")
 		}
+		to := common.HexToAddress(sendTo)
 
-		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
-		if err != nil {
-			return fmt.Errorf("invalid private key in config: %w", err)
-		}
-
-		chainIDHex, err := jsonRPCCall(cfg.MonadRPC, "eth_chainId", []interface{}{})
+		key, err := signingKey()
 		if err != nil {
-			return fmt.Errorf("failed to fetch chain id: %w", err)
-		}
-		chainID := hexToBigInt(chainIDHex)
-		if chainID == nil {
-			return fmt.Errorf("invalid chain id: %s", chainIDHex)
+			return err
 		}
 
-		nonceHex, err := jsonRPCCall(cfg.MonadRPC, "eth_getTransactionCount", []interface{}{cfg.WalletAddress, "pending"})
+		ctx := cmd.Context()
+		client, err := dialChain(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to fetch nonce: %w", err)
-		}
-		nonce := hexToBigInt(nonceHex)
-		if nonce == nil {
-			return fmt.Errorf("invalid nonce: %s", nonceHex)
+			return err
 		}
+		defer client.Close()
 
-		gasPriceHex, err := jsonRPCCall(cfg.MonadRPC, "eth_gasPrice", []interface{}{})
+		opts, err := transactor(ctx, client, key)
 		if err != nil {
-			return fmt.Errorf("failed to fetch gas price: %w", err)
-		}
-		gasPrice := hexToBigInt(gasPriceHex)
-		if gasPrice == nil {
-			return fmt.Errorf("invalid gas price: %s", gasPriceHex)
+			return err
 		}
 
-		var (
-			to       common.Address
-			value    = big.NewInt(0)
-			data     []byte
-			gasLimit uint64
-		)
-
+		var tx *types.Transaction
 		switch strings.ToLower(sendToken) {
 		case "mon":
-			to = common.HexToAddress(sendTo)
-			gasLimit = 21_000
-			value = monToWei(sendAmount)
+			value := monToWei(sendAmount)
 			if value == nil || value.Sign() <= 0 {
 				return fmt.Errorf("invalid MON amount: %f", sendAmount)
 			}
+			tx, err = client.TransferValue(opts, to, value)
 		case "usdc":
-			if !common.IsHexAddress(cfg.USDCAddress) {
-				return fmt.Errorf("invalid USDC contract address in config: %s", cfg.USDCAddress)
+			usdc, usdcErr := usdcContract(client)
+			if usdcErr != nil {
+				return usdcErr
 			}
-			to = common.HexToAddress(cfg.USDCAddress)
-			gasLimit = 100_000
-			data, err = buildERC20TransferData(sendTo, sendAmount, 6)
-			if err != nil {
-				return err
+			units, unitsErr := tokenUnits(sendAmount, 6)
+			if unitsErr != nil {
+				return unitsErr
 			}
+			tx, err = usdc.Transfer(opts, to, units)
 		default:
 			return fmt.Errorf("unsupported token %q (use mon|usdc)", sendToken)
 		}
+		if err != nil {
+			return fmt.Errorf("failed to send transaction: %w", err)
+		}
+		progress("Tx: %s", tx.Hash().Hex())
+
+		receipt, err := waitForReceipt(ctx, client, tx.Hash())
+		if err != nil {
+			return fmt.Errorf("send failed: %w", err)
+		}
+		return printResult(transfer{
+			From:    chain.AddressOf(key).Hex(),
+			To:      to.Hex(),
+			Amount:  strconv.FormatFloat(sendAmount, 'f', -1, 64),
+			Token:   strings.ToUpper(sendToken),
+			TxHash:  tx.Hash().Hex(),
+			Block:   receipt.BlockNumber.Uint64(),
+			GasUsed: receipt.GasUsed,
+		})
+	},
+}
+
+// transfer is the result of wallet send.
+type transfer struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Amount  string `json:"amount"`
+	Token   string `json:"token"` // MON or USDC
+	TxHash  string `json:"txHash"`
+	Block   uint64 `json:"block"`
+	GasUsed uint64 `json:"gasUsed"`
+}
+
+// ===== wallet allowance =====
+var walletAllowanceCmd = &cobra.Command{
+	Use:   "allowance",
+	Short: "Show or revoke the USDC allowance granted to a spender",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spenderFlag, _ := cmd.Flags().GetString("spender")
+		ownerFlag, _ := cmd.Flags().GetString("owner")
+		revoke, _ := cmd.Flags().GetBool("revoke")
 
-		tx := types.NewTransaction(nonce.Uint64(), to, value, gasLimit, gasPrice, data)
-		signer := types.NewEIP155Signer(chainID)
-		signedTx, err := types.SignTx(tx, signer, privateKey)
+		if spenderFlag == "" {
+			spenderFlag = cfg.ContractAddress
+		}
+		spender, err := chain.ParseAddress("spender", spenderFlag)
+		if err != nil {
+			return fmt.Errorf("%w (pass --spender or set contract_address in config)", err)
+		}
+		if ownerFlag == "" {
+			ownerFlag = walletAddress()
+		}
+		owner, err := chain.ParseAddress("owner", ownerFlag)
 		if err != nil {
-			return fmt.Errorf("failed to sign transaction: %w", err)
+			return fmt.Errorf("%w (pass --owner or run: buddyevents wallet setup)", err)
 		}
 
-		rawTx, err := signedTx.MarshalBinary()
+		ctx := cmd.Context()
+		client, err := dialChain(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to encode transaction: %w", err)
+			return err
 		}
-		rawTxHex := "0x" + hex.EncodeToString(rawTx)
+		defer client.Close()
 
-		txHash, err := jsonRPCCall(cfg.MonadRPC, "eth_sendRawTransaction", []interface{}{rawTxHex})
+		usdc, err := usdcContract(client)
 		if err != nil {
-			return fmt.Errorf("failed to send transaction: %w", err)
+			return err
 		}
 
-		fmt.Printf("Sent %f %s to %s\n", sendAmount, strings.ToUpper(sendToken), sendTo)
-		fmt.Printf("Tx: %s\n", txHash)
-		return nil
+		current, err := usdc.Allowance(&bind.CallOpts{Context: ctx}, owner, spender)
+		if err != nil {
+			return fmt.Errorf("failed to read allowance: %w", err)
+		}
+		result := allowance{Owner: owner.Hex(), Spender: spender.Hex(), Allowance: formatUSDC(current)}
+
+		if !revoke {
+			return printResult(result)
+		}
+		if current.Sign() == 0 {
+			progress("Nothing to revoke")
+			return printResult(result)
+		}
+
+		key, err := signingKey()
+		if err != nil {
+			return err
+		}
+		if signer := crypto.PubkeyToAddress(key.PublicKey); signer != owner {
+			return fmt.Errorf("can only revoke allowances of the configured wallet %s", signer.Hex())
+		}
+		opts, err := transactor(ctx, client, key)
+		if err != nil {
+			return err
+		}
+
+		tx, err := usdc.Approve(opts, spender, big.NewInt(0))
+		if err != nil {
+			return fmt.Errorf("revoke failed: %w", err)
+		}
+		if _, err := waitForReceipt(ctx, client, tx.Hash()); err != nil {
+			return fmt.Errorf("revoke failed: %w", err)
+		}
+		progress("Revoked %s USDC", result.Allowance)
+		result.Allowance = formatUSDC(big.NewInt(0))
+		result.RevokeTx = tx.Hash().Hex()
+		return printResult(result)
 	},
 }
 
+// allowance is the result of wallet allowance; Allowance is in USDC and
+// RevokeTx is set after --revoke.
+type allowance struct {
+	Owner     string `json:"owner"`
+	Spender   string `json:"spender"`
+	Allowance string `json:"allowance"`
+	RevokeTx  string `json:"revokeTx,omitempty"`
+}
+
 func init() {
+	walletSetupCmd.Flags().Bool("mnemonic", false, "create a BIP-39 HD seed instead of a single key")
+	walletSetupCmd.Flags().Bool("import", false, "with --mnemonic, read an existing phrase from stdin")
+	walletSetupCmd.Flags().String("mnemonic-out", "", "with --mnemonic, write the new phrase to this file instead of stderr")
+	walletSetupCmd.Flags().Bool("force", false, "replace the wallet already configured")
 	walletCmd.AddCommand(walletSetupCmd)
+
+	walletDeriveCmd.Flags().Int("index", 0, "account index on m/44'/60'/0'/0/<index> (default: next unused)")
+	walletCmd.AddCommand(walletDeriveCmd)
+	walletCmd.AddCommand(walletAccountsCmd)
+
+	walletImportCmd.Flags().String("keystore", "", "existing keystore file to import")
+	walletImportCmd.Flags().String("keystore-passphrase-file", "", "file holding the passphrase of the imported keystore")
+	walletImportCmd.Flags().String("key-file", "", "file holding a hex private key (default: read from stdin)")
+	walletImportCmd.Flags().Bool("force", false, "replace the wallet already configured")
+	walletCmd.AddCommand(walletImportCmd)
+
+	walletExportCmd.Flags().Bool("private-key", false, "print the decrypted hex private key of the selected account instead of keystore JSON")
+	walletExportCmd.Flags().Bool("mnemonic", false, "print the decrypted HD seed phrase")
+	walletCmd.AddCommand(walletExportCmd)
+
+	walletCmd.AddCommand(walletMigrateCmd)
 	walletCmd.AddCommand(walletBalanceCmd)
 	walletCmd.AddCommand(walletFundCmd)
 	walletSendCmd.Flags().StringVar(&sendTo, "to", "", "recipient wallet address")
@@ -248,10 +688,16 @@ func init() {
 	_ = walletSendCmd.MarkFlagRequired("to")
 	_ = walletSendCmd.MarkFlagRequired("amount")
 	walletCmd.AddCommand(walletSendCmd)
+
+	walletAllowanceCmd.Flags().String("spender", "", "spender address (defaults to the BuddyEvents contract)")
+	walletAllowanceCmd.Flags().String("owner", "", "owner address (defaults to config wallet)")
+	walletAllowanceCmd.Flags().Bool("revoke", false, "set the allowance back to zero")
+	walletCmd.AddCommand(walletAllowanceCmd)
 }
 
-// jsonRPCCall makes a raw JSON-RPC call and returns the result string
-func jsonRPCCall(rpcURL, method string, params []interface{}) (string, error) {
+// jsonRPCCall makes a raw JSON-RPC call and returns the result string.
+// Only for read methods: the call is retried like a safe API request.
+func jsonRPCCall(ctx context.Context, rpcURL, method string, params []interface{}) (string, error) {
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"method":  method,
@@ -260,11 +706,26 @@ func jsonRPCCall(rpcURL, method string, params []interface{}) (string, error) {
 	}
 
 	body, _ := json.Marshal(payload)
-	resp, err := http.Post(rpcURL, "application/json", strings.NewReader(string(body)))
+	if timeout := requestTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp, err := sdk.DefaultRetryPolicy.Do(ctx, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return http.DefaultClient.Do(req)
+	})
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("RPC HTTP error (%d)", resp.StatusCode)
+	}
 
 	respBody, _ := io.ReadAll(resp.Body)
 
@@ -283,14 +744,6 @@ func jsonRPCCall(rpcURL, method string, params []interface{}) (string, error) {
 	return result.Result, nil
 }
 
-func hexToBigInt(hexStr string) *big.Int {
-	n := new(big.Int)
-	if _, ok := n.SetString(strings.TrimPrefix(hexStr, "0x"), 16); !ok {
-		return nil
-	}
-	return n
-}
-
 func monToWei(amount float64) *big.Int {
 	if !isFinitePositive(amount) {
 		return nil
@@ -301,10 +754,9 @@ func monToWei(amount float64) *big.Int {
 	return wei
 }
 
-func buildERC20TransferData(to string, amount float64, decimals int) ([]byte, error) {
-	if !common.IsHexAddress(to) {
-		return nil, fmt.Errorf("invalid recipient address: %s", to)
-	}
+// tokenUnits converts a human amount to integer units for a token with the
+// given decimals.
+func tokenUnits(amount float64, decimals int) (*big.Int, error) {
 	if !isFinitePositive(amount) {
 		return nil, fmt.Errorf("amount must be > 0")
 	}
@@ -316,19 +768,7 @@ func buildERC20TransferData(to string, amount float64, decimals int) ([]byte, er
 	if units.Sign() <= 0 {
 		return nil, fmt.Errorf("amount too small for token decimals")
 	}
-
-	selector := "a9059cbb" // transfer(address,uint256)
-	toNoPrefix := strings.TrimPrefix(strings.ToLower(to), "0x")
-	toPadded := strings.Repeat("0", 64-len(toNoPrefix)) + toNoPrefix
-	amountHex := fmt.Sprintf("%x", units)
-	amountPadded := strings.Repeat("0", 64-len(amountHex)) + amountHex
-
-	dataHex := selector + toPadded + amountPadded
-	data, err := hex.DecodeString(dataHex)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode ERC20 transfer data: %w", err)
-	}
-	return data, nil
+	return units, nil
 }
 
 func isFinitePositive(v float64) bool {
@@ -0,0 +1,504 @@
+// / cli/cmd/watch.go — Live stream of event changes as NDJSON
+// / events watch diffs successive snapshots (realtime or polled) into change records with resumable cursors.
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/internal/config"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+// Change record types, in the order they are emitted for one event.
+const (
+	watchCreated        = "created"
+	watchApproved       = "approved"
+	watchEdited         = "edited"
+	watchCancelled      = "cancelled"
+	watchSoldOut        = "sold_out"
+	watchSeatsRemaining = "seats_remaining"
+)
+
+// watchSnapshotsKept bounds how far back a --cursor can resume.
+const watchSnapshotsKept = 50
+
+// watchRecord is one line of events watch output.
+type watchRecord struct {
+	Cursor      string        `json:"cursor"` // pass to --cursor to resume after this record
+	Type        string        `json:"type"`
+	Time        time.Time     `json:"time"` // when the change was observed
+	EventID     string        `json:"eventId"`
+	Name        string        `json:"name"`
+	Changes     []fieldChange `json:"changes,omitempty"`     // edited only
+	TicketsLeft *int          `json:"ticketsLeft,omitempty"` // sold_out and seats_remaining only
+	Event       sdk.Event     `json:"event"`                 // the event after the change
+}
+
+// ===== events watch =====
+var eventsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream event changes as NDJSON",
+	Long: `Stream changes to events as they happen, one JSON object per line:
+
+  created          a new event
+  approved         an event passed moderation
+  edited           name, description, times, price, tickets, location or status
+                   changed ("changes" lists them)
+  cancelled        an event was cancelled
+  sold_out         the last ticket was sold
+  seats_remaining  the number of tickets left changed ("ticketsLeft")
+
+Changes arrive over the API's realtime stream when the deployment has one;
+otherwise events are polled every --interval. The first snapshot is the
+baseline and produces no records.
+
+Every record has a "cursor". Snapshots are kept under the config directory, so
+after a restart --cursor replays the records that came after that one, as
+they were printed, then reports what changed while watch was not running as
+one set of records. Only the newest 50 snapshots of a --session are kept:
+watchers running at the same time need their own --session, and resuming
+needs the --session the cursor came from.`,
+	Example: `  buddyevents events watch
+  buddyevents events watch --poll --interval 30s
+  buddyevents events watch --session sync --cursor "$(tail -n1 changes.ndjson | jq -r .cursor)" >> changes.ndjson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		poll, _ := cmd.Flags().GetBool("poll")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		cursor, _ := cmd.Flags().GetString("cursor")
+		session, _ := cmd.Flags().GetString("session")
+		if interval <= 0 {
+			return usageError{fmt.Errorf("--interval must be positive")}
+		}
+		if session == "" || session != filepath.Base(session) || strings.HasPrefix(session, ".") {
+			return usageError{fmt.Errorf("invalid --session %q (want a plain name)", session)}
+		}
+
+		client := apiClient()
+		w := &watcher{
+			store: watchStore{dir: filepath.Join(watchDir(client.BaseURL()), session)},
+			out:   bufio.NewWriter(os.Stdout),
+		}
+		if cursor != "" {
+			if err := w.resume(cursor); err != nil {
+				return err
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watchSnapshots(ctx, client, poll, interval, w.observe)
+	},
+}
+
+// watchDir keeps the snapshots of each deployment apart; each --session has
+// a directory under it.
+func watchDir(baseURL string) string {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, host)
+	return filepath.Join(config.Dir(configPath), "watch", host)
+}
+
+// watchSnapshots calls observe with every event, from the realtime stream
+// or, when the deployment has none or poll is set, every interval. Stream
+// and API failures are reported and retried; errors from observe end it.
+func watchSnapshots(ctx context.Context, client *sdk.Client, poll bool, interval time.Duration, observe func([]sdk.Event) error) error {
+	var observeErr error
+	handle := func(events []sdk.Event) error {
+		observeErr = observe(events)
+		return observeErr
+	}
+
+	backoff := time.Second
+	for !poll {
+		connected := false
+		err := client.StreamEvents(ctx, func(events []sdk.Event) error {
+			connected = true
+			return handle(events)
+		})
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case observeErr != nil:
+			return observeErr
+		case errors.Is(err, sdk.ErrStreamUnsupported):
+			progress("No realtime stream on this deployment; polling every %s", interval)
+			poll = true
+			continue
+		}
+		if connected {
+			backoff = time.Second
+		}
+		progress("warning: %v; reconnecting in %s", err, backoff)
+		if !sleepContext(ctx, backoff) {
+			return nil
+		}
+		backoff = min(2*backoff, time.Minute)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		events, err := client.ListEvents(ctx, "")
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			progress("warning: failed to list events: %v", err)
+		default:
+			if err := handle(events); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// watcher turns snapshots into records. last is the newest saved snapshot;
+// nil until the baseline arrives.
+type watcher struct {
+	store watchStore
+	out   *bufio.Writer
+	last  *watchSnapshot
+}
+
+// resume replays the records after cursor: the rest of its snapshot's, then
+// those of every later snapshot diffed from it, in turn. The newest of them
+// becomes the baseline.
+func (w *watcher) resume(cursor string) error {
+	id, index, err := parseWatchCursor(cursor)
+	if err != nil {
+		return usageError{err}
+	}
+	snapshot, err := w.store.load(id)
+	if errors.Is(err, os.ErrNotExist) {
+		return usageError{fmt.Errorf("cursor %q is unknown or too old to resume from", cursor)}
+	}
+	if err != nil {
+		return err
+	}
+	if snapshot.Prev != 0 {
+		prev, err := w.store.load(snapshot.Prev)
+		if err != nil {
+			return fmt.Errorf("failed to resume from cursor %q: %w", cursor, err)
+		}
+		records := diffEvents(prev.Events, snapshot.Events)
+		if index+1 < len(records) {
+			if err := w.emit(snapshot.ID, records, index+1); err != nil {
+				return err
+			}
+		}
+	}
+	w.last = snapshot
+
+	next, err := w.store.after(snapshot.ID)
+	if err != nil {
+		return fmt.Errorf("failed to resume from cursor %q: %w", cursor, err)
+	}
+	for _, later := range next {
+		if later.Prev != w.last.ID {
+			continue // a later run started its own chain
+		}
+		if err := w.emit(later.ID, diffEvents(w.last.Events, later.Events), 0); err != nil {
+			return err
+		}
+		w.last = later
+	}
+	return nil
+}
+
+func (w *watcher) observe(events []sdk.Event) error {
+	if w.last == nil {
+		return w.save(events)
+	}
+	records := diffEvents(w.last.Events, events)
+	if len(records) == 0 {
+		return nil
+	}
+	if err := w.save(events); err != nil {
+		return err
+	}
+	return w.emit(w.last.ID, records, 0)
+}
+
+// save records events as the newest snapshot. Its ID, the observation time
+// in unix ms, always increases so that cursors sort.
+func (w *watcher) save(events []sdk.Event) error {
+	snapshot := &watchSnapshot{ID: time.Now().UnixMilli(), Events: events}
+	if w.last != nil {
+		snapshot.Prev = w.last.ID
+		snapshot.ID = max(snapshot.ID, w.last.ID+1)
+	}
+	if err := w.store.save(snapshot); err != nil {
+		return fmt.Errorf("failed to save watch snapshot: %w", err)
+	}
+	w.last = snapshot
+	return nil
+}
+
+// emit writes records[from:], which were observed in snapshot id.
+func (w *watcher) emit(id int64, records []watchRecord, from int) error {
+	observed := time.UnixMilli(id).UTC()
+	for i := from; i < len(records); i++ {
+		record := records[i]
+		record.Cursor = fmt.Sprintf("%d.%d", id, i)
+		record.Time = observed
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		w.out.Write(line)
+		w.out.WriteByte('\n')
+	}
+	// Flush per snapshot, so a consumer sees changes as they happen.
+	return w.out.Flush()
+}
+
+func parseWatchCursor(cursor string) (int64, int, error) {
+	idText, indexText, ok := strings.Cut(cursor, ".")
+	id, idErr := strconv.ParseInt(idText, 10, 64)
+	index, indexErr := strconv.Atoi(indexText)
+	if !ok || idErr != nil || indexErr != nil || id <= 0 || index < 0 {
+		return 0, 0, fmt.Errorf("invalid --cursor %q (want a cursor printed by events watch)", cursor)
+	}
+	return id, index, nil
+}
+
+// diffEvents lists what changed from before to after. Events are visited by
+// ID so that the same snapshots always give the same records, which is what
+// makes cursors replayable. Events that disappear are not reported.
+func diffEvents(before, after []sdk.Event) []watchRecord {
+	previous := make(map[string]sdk.Event, len(before))
+	for _, event := range before {
+		previous[event.ID] = event
+	}
+	events := slices.Clone(after)
+	slices.SortFunc(events, func(a, b sdk.Event) int { return strings.Compare(a.ID, b.ID) })
+
+	var records []watchRecord
+	for _, event := range events {
+		record := func(kind string) watchRecord {
+			return watchRecord{Type: kind, EventID: event.ID, Name: event.Name, Event: event}
+		}
+		old, ok := previous[event.ID]
+		if !ok {
+			records = append(records, record(watchCreated))
+			continue
+		}
+		if moderationStatus(old) != sdk.ModerationApproved && moderationStatus(event) == sdk.ModerationApproved {
+			records = append(records, record(watchApproved))
+		}
+		if changes := eventChanges(old, event); len(changes) > 0 {
+			edited := record(watchEdited)
+			edited.Changes = changes
+			records = append(records, edited)
+		}
+		if old.Status != sdk.EventCancelled && event.Status == sdk.EventCancelled {
+			records = append(records, record(watchCancelled))
+		}
+		if left := event.TicketsLeft(); left != old.TicketsLeft() {
+			kind := watchSeatsRemaining
+			if left == 0 {
+				kind = watchSoldOut
+			}
+			seats := record(kind)
+			seats.TicketsLeft = &left
+			records = append(records, seats)
+		}
+	}
+	return records
+}
+
+// moderationStatus treats events from before moderation as approved, as the
+// API does.
+func moderationStatus(event sdk.Event) sdk.ModerationStatus {
+	if event.ModerationStatus == "" {
+		return sdk.ModerationApproved
+	}
+	return event.ModerationStatus
+}
+
+// eventChanges lists the edited fields. Cancelling is its own record, so a
+// change to cancelled is left out.
+func eventChanges(old, event sdk.Event) []fieldChange {
+	var changes []fieldChange
+	changed := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fieldChange{Field: field, From: from, To: to})
+		}
+	}
+	utc := func(m sdk.Millis) string {
+		return m.Time().UTC().Format(time.RFC3339)
+	}
+
+	changed("name", old.Name, event.Name)
+	changed("description", old.Description, event.Description)
+	changed("startTime", utc(old.StartTime), utc(event.StartTime))
+	changed("endTime", utc(old.EndTime), utc(event.EndTime))
+	changed("price", fmt.Sprint(old.Price), fmt.Sprint(event.Price))
+	changed("maxTickets", strconv.Itoa(old.MaxTickets), strconv.Itoa(event.MaxTickets))
+	changed("location", old.Location, event.Location)
+	if event.Status != sdk.EventCancelled {
+		changed("status", string(old.Status), string(event.Status))
+	}
+	return changes
+}
+
+// watchSnapshot is every event as observed at one time. Prev is the
+// snapshot the records of this one were diffed against; 0 for a baseline.
+type watchSnapshot struct {
+	ID     int64       `json:"id"`
+	Prev   int64       `json:"prev,omitempty"`
+	Events []sdk.Event `json:"events"`
+}
+
+// watchStore keeps the newest snapshots, one <id>.json file each.
+type watchStore struct {
+	dir string
+}
+
+func (s watchStore) path(id int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(id, 10)+".json")
+}
+
+func (s watchStore) load(id int64) (*watchSnapshot, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+	var snapshot watchSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid watch snapshot %s: %w", s.path(id), err)
+	}
+	return &snapshot, nil
+}
+
+// after loads the snapshots newer than id, oldest first.
+func (s watchStore) after(id int64) ([]*watchSnapshot, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []*watchSnapshot
+	for _, later := range ids {
+		if later <= id {
+			continue
+		}
+		snapshot, err := s.load(later)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // pruned meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// ids lists the saved snapshot IDs in increasing order.
+func (s watchStore) ids() ([]int64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, entry := range entries {
+		if id, err := strconv.ParseInt(strings.TrimSuffix(entry.Name(), ".json"), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// save writes the snapshot atomically, then removes all but the newest
+// watchSnapshotsKept.
+func (s watchStore) save(snapshot *watchSnapshot) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(snapshot.ID)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return s.prune()
+}
+
+func (s watchStore) prune() error {
+	ids, err := s.ids()
+	if err != nil {
+		return err
+	}
+	for len(ids) > watchSnapshotsKept {
+		if err := os.Remove(s.path(ids[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+func init() {
+	eventsWatchCmd.Flags().Bool("poll", false, "Poll instead of using the realtime stream")
+	eventsWatchCmd.Flags().Duration("interval", 15*time.Second, "How often to poll when there is no realtime stream")
+	eventsWatchCmd.Flags().String("cursor", "", "Resume after this record's cursor")
+	eventsWatchCmd.Flags().String("session", "default", "Name under which snapshots are kept; concurrent watchers need different ones")
+
+	eventsCmd.AddCommand(eventsWatchCmd)
+}
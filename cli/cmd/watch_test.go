@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+)
+
+func TestWatchResumeReplaysChain(t *testing.T) {
+	store := watchStore{dir: t.TempDir()}
+	event := sdk.Event{ID: "e1", Name: "Meetup", MaxTickets: 3, Status: sdk.EventActive}
+	snapshot := func(sold int, name string) []sdk.Event {
+		e := event
+		e.TicketsSold, e.Name = sold, name
+		return []sdk.Event{e}
+	}
+
+	var live bytes.Buffer
+	w := &watcher{store: store, out: bufio.NewWriter(&live)}
+	for _, events := range [][]sdk.Event{
+		snapshot(0, "Meetup"),
+		snapshot(1, "Meetup"),
+		snapshot(2, "Meetup 2"),
+		snapshot(3, "Meetup 2"),
+	} {
+		if err := w.observe(events); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records := readWatchRecords(t, &live)
+	if len(records) != 4 {
+		t.Fatalf("got %d live records, want 4", len(records))
+	}
+
+	for from := range records {
+		var replay bytes.Buffer
+		r := &watcher{store: store, out: bufio.NewWriter(&replay)}
+		if err := r.resume(records[from].Cursor); err != nil {
+			t.Fatal(err)
+		}
+		got := readWatchRecords(t, &replay)
+		want := records[from+1:]
+		if len(got) != len(want) {
+			t.Fatalf("resume %s: got %d records, want %d", records[from].Cursor, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Cursor != want[i].Cursor || got[i].Type != want[i].Type {
+				t.Errorf("resume %s: record %d = %s %s, want %s %s",
+					records[from].Cursor, i, got[i].Cursor, got[i].Type, want[i].Cursor, want[i].Type)
+			}
+		}
+		if r.last.ID != w.last.ID {
+			t.Errorf("resume %s: baseline %d, want the newest snapshot %d", records[from].Cursor, r.last.ID, w.last.ID)
+		}
+	}
+}
+
+func readWatchRecords(t *testing.T, out *bytes.Buffer) []watchRecord {
+	t.Helper()
+	var records []watchRecord
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record watchRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
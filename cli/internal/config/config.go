@@ -1,21 +1,54 @@
 /// cli/internal/config/config.go — CLI configuration management
-/// Stores API URLs, wallet info, contract addresses
+/// Stores API URLs, wallet info, contract addresses, and named profiles
 package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 type Config struct {
-	APIURL          string `json:"api_url"`
-	ConvexURL       string `json:"convex_url"`
-	MonadRPC        string `json:"monad_rpc"`
-	WalletAddress   string `json:"wallet_address"`
-	PrivateKey      string `json:"private_key"`
-	ContractAddress string `json:"contract_address"`
-	USDCAddress     string `json:"usdc_address"`
+	APIURL          string    `json:"api_url"`
+	ConvexURL       string    `json:"convex_url"`
+	APIToken        string    `json:"api_token,omitempty"` // bearer token from `buddyevents login`
+	MonadRPC        string    `json:"monad_rpc"`
+	ChainID         uint64    `json:"chain_id,omitempty"` // expected chain; 0 accepts whatever the RPC serves
+	WalletAddress   string    `json:"wallet_address"`
+	Keystore        string    `json:"keystore,omitempty"`        // encrypted key file (Web3 Secret Storage)
+	PassphraseFile  string    `json:"passphrase_file,omitempty"` // unlocks Keystore for headless agents
+	PrivateKey      string    `json:"private_key,omitempty"`     // legacy plaintext key; see `wallet migrate`
+	Seed            string    `json:"seed,omitempty"`            // encrypted BIP-39 mnemonic file
+	Accounts        []Account `json:"accounts,omitempty"`        // accounts derived from Seed
+	Account         string    `json:"account,omitempty"`         // default --account selector
+	ContractAddress string    `json:"contract_address"`
+	USDCAddress     string    `json:"usdc_address"`
+	Timezone        string    `json:"timezone,omitempty"` // IANA zone for reading and showing times; empty is the system zone
+
+	Profile  string             `json:"profile,omitempty"` // active profile
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+
+	sources map[string]Source // layer that set each key; see Source
+}
+
+// Profile overrides the network settings of Config for one environment.
+// Empty fields fall back to the top-level values.
+type Profile struct {
+	APIURL          string `json:"api_url,omitempty"`
+	ConvexURL       string `json:"convex_url,omitempty"`
+	MonadRPC        string `json:"monad_rpc,omitempty"`
+	ChainID         uint64 `json:"chain_id,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	USDCAddress     string `json:"usdc_address,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+// Account is an HD account derived at m/44'/60'/0'/0/<Index>.
+type Account struct {
+	Index   uint32 `json:"index"`
+	Address string `json:"address"`
 }
 
 func Default() *Config {
@@ -24,17 +57,110 @@ func Default() *Config {
 		ConvexURL:       "",
 		MonadRPC:        "https://testnet-rpc.monad.xyz",
 		WalletAddress:   "",
-		PrivateKey:      "",
 		ContractAddress: "",
 		USDCAddress:     "0x534b2f3A21130d7a60830c2Df862319e593943A3",
 	}
 }
 
+// presets seed `config profiles create --from`. Deployment-specific values
+// (contract, mainnet USDC, hosted API) are left for the user to fill in.
+var presets = map[string]Profile{
+	"local": {
+		APIURL:   "http://localhost:3000",
+		MonadRPC: "http://127.0.0.1:8545",
+		ChainID:  31337,
+	},
+	"testnet": {
+		MonadRPC:    "https://testnet-rpc.monad.xyz",
+		ChainID:     10143,
+		USDCAddress: "0x534b2f3A21130d7a60830c2Df862319e593943A3",
+	},
+	"mainnet": {
+		MonadRPC: "https://rpc.monad.xyz",
+		ChainID:  143,
+	},
+}
+
+// Preset returns the built-in profile template called name.
+func Preset(name string) (Profile, bool) {
+	p, ok := presets[name]
+	return p, ok
+}
+
+// PresetNames lists the built-in profile templates.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileNames lists the configured profiles in order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns a copy of c with profile name applied on top. An
+// empty name selects c.Profile; no active profile returns c unchanged.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	if name == "" {
+		name = c.Profile
+	}
+	out := *c
+	out.sources = make(map[string]Source, len(c.sources))
+	for k, v := range c.sources {
+		out.sources[k] = v
+	}
+	if name == "" {
+		return &out, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+
+	out.Profile = name
+	override := func(key string, dst *string, v string) {
+		if v != "" {
+			*dst = v
+			out.setSource(key, SourceProfile)
+		}
+	}
+	override("api_url", &out.APIURL, p.APIURL)
+	override("convex_url", &out.ConvexURL, p.ConvexURL)
+	override("monad_rpc", &out.MonadRPC, p.MonadRPC)
+	override("contract_address", &out.ContractAddress, p.ContractAddress)
+	override("usdc_address", &out.USDCAddress, p.USDCAddress)
+	override("account", &out.Account, p.Account)
+	if p.ChainID != 0 {
+		out.ChainID = p.ChainID
+		out.setSource("chain_id", SourceProfile)
+	}
+	return &out, nil
+}
+
 func configDir() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".buddyevents")
 }
 
+// Dir is the directory holding the config file; keystores live beside it.
+func Dir(custom string) string {
+	return filepath.Dir(configPath(custom))
+}
+
+// Path is the config file selected by custom (the --config flag).
+func Path(custom string) string {
+	return configPath(custom)
+}
+
 func configPath(custom string) string {
 	if custom != "" {
 		return custom
@@ -53,16 +179,23 @@ func Load(custom string) (*Config, error) {
 	if err := json.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
+
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(data, &present); err != nil {
+		return nil, err
+	}
+	for key := range present {
+		cfg.setSource(key, SourceFile)
+	}
 	return cfg, nil
 }
 
 func Save(cfg *Config, custom string) error {
-	dir := configDir()
-	if err := os.MkdirAll(dir, 0700); err != nil {
+	path := configPath(custom)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return err
 	}
 
-	path := configPath(custom)
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
@@ -0,0 +1,237 @@
+// / cli/internal/config/fields.go — Key-based access to config fields
+// / Backs `config get/set/unset/show` and tracks where each value came from.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Source names the layer that supplied an effective value.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceProfile Source = "profile"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// secretKeys are redacted by Display.
+var secretKeys = map[string]bool{
+	"private_key": true,
+	"api_token":   true,
+}
+
+// addressKeys must hold 0x-prefixed 20-byte hex addresses.
+var addressKeys = map[string]bool{
+	"wallet_address":   true,
+	"contract_address": true,
+	"usdc_address":     true,
+}
+
+// urlKeys must hold absolute http(s) URLs.
+var urlKeys = map[string]bool{
+	"api_url":    true,
+	"convex_url": true,
+	"monad_rpc":  true,
+}
+
+// Keys lists the scalar settings addressable by name, in file order.
+// Structured settings (accounts, profiles) have their own commands.
+func Keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonName(f)
+		if name == "" || (f.Type.Kind() != reflect.String && f.Type.Kind() != reflect.Uint64) {
+			continue
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// EnvPrefix namespaces the environment variables that override config keys.
+const EnvPrefix = "BUDDYEVENTS_"
+
+// EnvVar is the environment variable that overrides key, e.g.
+// BUDDYEVENTS_MONAD_RPC for monad_rpc.
+func EnvVar(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// ApplyEnv overrides every key whose environment variable is set and
+// non-empty. The profile key is skipped: it is resolved before profiles apply.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	for _, key := range Keys() {
+		if key == "profile" {
+			continue
+		}
+		if value, ok := lookup(EnvVar(key)); ok && value != "" {
+			if err := c.Assign(key, value); err != nil {
+				return fmt.Errorf("%s: %w", EnvVar(key), err)
+			}
+			c.setSource(key, SourceEnv)
+		}
+	}
+	return nil
+}
+
+// IsSecret reports whether key holds key material.
+func IsSecret(key string) bool {
+	return secretKeys[key]
+}
+
+// Get returns the value of key formatted as a string.
+func (c *Config) Get(key string) (string, error) {
+	v, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+	if v.Kind() == reflect.Uint64 {
+		if v.Uint() == 0 {
+			return "", nil
+		}
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return v.String(), nil
+}
+
+// Display is Get with secrets redacted.
+func (c *Config) Display(key string) (string, error) {
+	value, err := c.Get(key)
+	if err != nil || value == "" || !IsSecret(key) {
+		return value, err
+	}
+	return "<redacted>", nil
+}
+
+// Set validates a value the user typed and assigns it to key. The profile
+// key only accepts profiles that exist, and private_key is refused: keys
+// belong in a keystore.
+func (c *Config) Set(key, value string) error {
+	switch {
+	case key == "private_key" && value != "":
+		return fmt.Errorf("private_key cannot be set; store the key encrypted with: buddyevents wallet import")
+	case key == "profile" && value != "":
+		if _, ok := c.Profiles[value]; !ok {
+			return fmt.Errorf("unknown profile %q (want one of: %s)", value, strings.Join(c.ProfileNames(), ", "))
+		}
+	}
+	return c.Assign(key, value)
+}
+
+// Assign validates value and assigns it to key, without Set's checks. It
+// carries values from other layers: the environment, flags, defaults and the
+// wallet commands' own changes.
+func (c *Config) Assign(key, value string) error {
+	v, err := c.field(key)
+	if err != nil {
+		return err
+	}
+	if err := Validate(key, value); err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Uint64 {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil && value != "" {
+			return fmt.Errorf("%s must be a non-negative integer", key)
+		}
+		v.SetUint(n)
+		return nil
+	}
+	v.SetString(value)
+	return nil
+}
+
+// Unset restores key to its default value.
+func (c *Config) Unset(key string) error {
+	if _, err := c.field(key); err != nil {
+		return err
+	}
+	value, _ := Default().Get(key)
+	return c.Assign(key, value)
+}
+
+// Override sets key from a higher-precedence layer and records the source.
+func (c *Config) Override(key, value string, source Source) error {
+	if err := c.Assign(key, value); err != nil {
+		return fmt.Errorf("%s (from %s): %w", key, source, err)
+	}
+	c.setSource(key, source)
+	return nil
+}
+
+// Source reports which layer supplied the effective value of key.
+func (c *Config) Source(key string) Source {
+	if s, ok := c.sources[key]; ok {
+		return s
+	}
+	return SourceDefault
+}
+
+func (c *Config) setSource(key string, source Source) {
+	if c.sources == nil {
+		c.sources = map[string]Source{}
+	}
+	c.sources[key] = source
+}
+
+func (c *Config) field(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) == key {
+			f := v.Field(i)
+			if f.Kind() == reflect.String || f.Kind() == reflect.Uint64 {
+				return f, nil
+			}
+			break
+		}
+	}
+	keys := Keys()
+	sort.Strings(keys)
+	return reflect.Value{}, fmt.Errorf("unknown config key %q (want one of: %s)", key, strings.Join(keys, ", "))
+}
+
+// Validate checks the format of value for key; empty values always pass.
+func Validate(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch {
+	case addressKeys[key]:
+		if !common.IsHexAddress(value) || !strings.HasPrefix(value, "0x") {
+			return fmt.Errorf("%s must be a 0x-prefixed hex address, got %q", key, value)
+		}
+	case urlKeys[key]:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an http(s) URL, got %q", key, value)
+		}
+	case key == "timezone":
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("timezone must be an IANA zone such as Europe/Rome or UTC, got %q", value)
+		}
+	}
+	return nil
+}
+
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
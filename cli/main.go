@@ -2,7 +2,7 @@
 /// Pi agent uses this via its Bash tool to manage events and tickets
 package main
 
-import "buddyevents/cmd"
+import "github.com/OxFrancesco/BuddyEvents/cli/cmd"
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,203 @@
+// / cli/sdk/auth.go — Bearer tokens for authenticated API calls
+// / Static API keys, and Clerk session JWTs refreshed before they expire.
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+)
+
+// TokenSource supplies the bearer token sent with each request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) { return string(t), nil }
+
+// WithToken authenticates every request with a fixed bearer token, e.g. a
+// Clerk API key. Use WithTokenSource to refresh session tokens.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		if token != "" {
+			c.tokens = staticToken(token)
+		}
+	}
+}
+
+// WithTokenSource authenticates every request with a token from src.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *Client) { c.tokens = src }
+}
+
+// refreshMargin is how long before expiry a session token is replaced.
+const refreshMargin = 30 * time.Second
+
+// RefreshingToken serves a Clerk session JWT and swaps it for a fresh one
+// shortly before it expires. Tokens that are not JWTs (API keys) are served
+// unchanged.
+type RefreshingToken struct {
+	// OnRefresh, if set, is called with each new token, e.g. to persist it.
+	OnRefresh func(token string)
+
+	mu      sync.Mutex
+	token   string
+	refresh *Client
+}
+
+// NewRefreshingToken returns a TokenSource that starts from token and
+// refreshes it against the deployment at baseURL.
+func NewRefreshingToken(baseURL, token string, opts ...Option) *RefreshingToken {
+	return &RefreshingToken{token: token, refresh: NewClient(baseURL, opts...)}
+}
+
+func (r *RefreshingToken) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiry, ok := TokenExpiry(r.token)
+	if !ok || time.Until(expiry) > refreshMargin {
+		return r.token, nil
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	client := *r.refresh
+	client.tokens = staticToken(r.token)
+	if err := client.do(ctx, http.MethodPost, "/api/auth/session", nil, nil, &result); err != nil {
+		if time.Now().Before(expiry) {
+			return r.token, nil // still valid; try again on the next call
+		}
+		return "", fmt.Errorf("session token expired and refresh failed: %w", err)
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("invalid API response: missing token")
+	}
+	r.token = result.Token
+	if r.OnRefresh != nil {
+		r.OnRefresh(r.token)
+	}
+	return r.token, nil
+}
+
+// TokenExpiry reads the exp claim of a JWT. It does not verify the
+// signature; ok is false for tokens that are not JWTs.
+func TokenExpiry(token string) (expiry time.Time, ok bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// Session describes the caller behind the client's token.
+type Session struct {
+	ClerkID       string `json:"clerkId"`
+	TokenType     string `json:"tokenType"`
+	UserID        string `json:"userId"`
+	Role          string `json:"role"`
+	WalletAddress string `json:"walletAddress"`
+}
+
+// WhoAmI returns the identity the API sees for the current token.
+func (c *Client) WhoAmI(ctx context.Context) (*Session, error) {
+	var result Session
+	if err := c.do(ctx, http.MethodGet, "/api/auth/session", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WalletChallenge is what the API returns for a Sign-In with Ethereum nonce
+// request: the fields of the EIP-4361 message the wallet must sign.
+type WalletChallenge struct {
+	Nonce          string `json:"nonce"`
+	Domain         string `json:"domain"`
+	URI            string `json:"uri"`
+	Statement      string `json:"statement"`
+	IssuedAt       string `json:"issuedAt"`
+	ExpirationTime string `json:"expirationTime"`
+	ChainID        uint64 `json:"chainId,omitempty"` // chain the server accepts; 0 from older servers
+}
+
+// Message renders the EIP-4361 message for address on chainID.
+func (w *WalletChallenge) Message(address string, chainID uint64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s wants you to sign in with your Ethereum account:\n%s\n\n", w.Domain, address)
+	if w.Statement != "" {
+		fmt.Fprintf(&b, "%s\n", w.Statement)
+	}
+	fmt.Fprintf(&b, "\nURI: %s\nVersion: 1\nChain ID: %d\nNonce: %s\nIssued At: %s", w.URI, chainID, w.Nonce, w.IssuedAt)
+	if w.ExpirationTime != "" {
+		fmt.Fprintf(&b, "\nExpiration Time: %s", w.ExpirationTime)
+	}
+	return b.String()
+}
+
+// WalletLogin is the API token issued for a signed wallet challenge.
+type WalletLogin struct {
+	Token         string `json:"token"`
+	ExpiresAt     Millis `json:"expiresAt"`
+	ClerkID       string `json:"clerkId"`
+	WalletAddress string `json:"walletAddress"`
+}
+
+// WalletChallenge requests a single-use sign-in nonce for address.
+func (c *Client) WalletChallenge(ctx context.Context, address string) (*WalletChallenge, error) {
+	var result WalletChallenge
+	if err := c.do(ctx, http.MethodPost, "/api/auth/wallet/nonce", nil, map[string]string{"address": address}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LoginWithWallet signs in as the owner of key (Sign-In with Ethereum) and
+// returns an API token for use with WithToken. Wallets without an account
+// get one on first login.
+func (c *Client) LoginWithWallet(ctx context.Context, key *ecdsa.PrivateKey, chainID uint64) (*WalletLogin, error) {
+	address := chain.AddressOf(key).Hex()
+	challenge, err := c.WalletChallenge(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sign-in nonce: %w", err)
+	}
+	if challenge.ChainID != 0 && challenge.ChainID != chainID {
+		return nil, fmt.Errorf("the API signs in wallets on chain %d, not %d; check chain_id and monad_rpc", challenge.ChainID, chainID)
+	}
+	message := challenge.Message(address, chainID)
+	signature, err := chain.SignMessage(key, message)
+	if err != nil {
+		return nil, err
+	}
+	var result WalletLogin
+	if err := c.do(ctx, http.MethodPost, "/api/auth/wallet", nil, map[string]string{
+		"message":   message,
+		"signature": signature,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Token == "" {
+		return nil, fmt.Errorf("invalid API response: missing token")
+	}
+	return &result, nil
+}
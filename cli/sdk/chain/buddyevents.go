@@ -0,0 +1,255 @@
+// / cli/sdk/chain/buddyevents.go — Typed bindings for BuddyEvents.sol
+// / Mirrors contracts/src/BuddyEvents.sol; keep the ABI in sync on contract changes.
+package chain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const BuddyEventsABI = `[
+  {"type":"function","name":"createEvent","stateMutability":"nonpayable",
+   "inputs":[{"name":"name","type":"string"},{"name":"priceInUSDC","type":"uint256"},{"name":"maxTickets","type":"uint256"}],
+   "outputs":[{"name":"eventId","type":"uint256"}]},
+  {"type":"function","name":"editEvent","stateMutability":"nonpayable",
+   "inputs":[{"name":"eventId","type":"uint256"},{"name":"name","type":"string"},{"name":"priceInUSDC","type":"uint256"}],
+   "outputs":[]},
+  {"type":"function","name":"cancelEvent","stateMutability":"nonpayable",
+   "inputs":[{"name":"eventId","type":"uint256"}],
+   "outputs":[]},
+  {"type":"function","name":"buyTicket","stateMutability":"nonpayable",
+   "inputs":[{"name":"eventId","type":"uint256"}],
+   "outputs":[{"name":"tokenId","type":"uint256"}]},
+  {"type":"function","name":"listTicket","stateMutability":"nonpayable",
+   "inputs":[{"name":"tokenId","type":"uint256"},{"name":"price","type":"uint256"}],
+   "outputs":[]},
+  {"type":"function","name":"delistTicket","stateMutability":"nonpayable",
+   "inputs":[{"name":"tokenId","type":"uint256"}],
+   "outputs":[]},
+  {"type":"function","name":"buyListedTicket","stateMutability":"nonpayable",
+   "inputs":[{"name":"tokenId","type":"uint256"}],
+   "outputs":[]},
+  {"type":"function","name":"getEvent","stateMutability":"view",
+   "inputs":[{"name":"eventId","type":"uint256"}],
+   "outputs":[{"name":"name","type":"string"},{"name":"priceInUSDC","type":"uint256"},{"name":"maxTickets","type":"uint256"},
+              {"name":"ticketsSold","type":"uint256"},{"name":"organizer","type":"address"},{"name":"active","type":"bool"}]},
+  {"type":"function","name":"getListing","stateMutability":"view",
+   "inputs":[{"name":"tokenId","type":"uint256"}],
+   "outputs":[{"name":"price","type":"uint256"},{"name":"seller","type":"address"},{"name":"active","type":"bool"}]},
+  {"type":"event","name":"EventCreated","anonymous":false,
+   "inputs":[{"name":"eventId","type":"uint256","indexed":true},{"name":"name","type":"string","indexed":false},
+             {"name":"price","type":"uint256","indexed":false},{"name":"maxTickets","type":"uint256","indexed":false},
+             {"name":"organizer","type":"address","indexed":true}]},
+  {"type":"event","name":"EventUpdated","anonymous":false,
+   "inputs":[{"name":"eventId","type":"uint256","indexed":true},{"name":"name","type":"string","indexed":false},
+             {"name":"price","type":"uint256","indexed":false}]},
+  {"type":"event","name":"EventCancelled","anonymous":false,
+   "inputs":[{"name":"eventId","type":"uint256","indexed":true}]},
+  {"type":"event","name":"TicketPurchased","anonymous":false,
+   "inputs":[{"name":"eventId","type":"uint256","indexed":true},{"name":"tokenId","type":"uint256","indexed":true},
+             {"name":"buyer","type":"address","indexed":true},{"name":"price","type":"uint256","indexed":false}]},
+  {"type":"event","name":"TicketListed","anonymous":false,
+   "inputs":[{"name":"tokenId","type":"uint256","indexed":true},{"name":"price","type":"uint256","indexed":false},
+             {"name":"seller","type":"address","indexed":true}]},
+  {"type":"event","name":"TicketDelisted","anonymous":false,
+   "inputs":[{"name":"tokenId","type":"uint256","indexed":true}]},
+  {"type":"event","name":"TicketSold","anonymous":false,
+   "inputs":[{"name":"tokenId","type":"uint256","indexed":true},{"name":"price","type":"uint256","indexed":false},
+             {"name":"seller","type":"address","indexed":false},{"name":"buyer","type":"address","indexed":false}]}
+]`
+
+var buddyEventsABI = mustParseABI(BuddyEventsABI)
+
+// Event is the on-chain view returned by getEvent.
+type Event struct {
+	Name        string
+	PriceInUSDC *big.Int // 6 decimals: 1 USDC = 1_000_000
+	MaxTickets  *big.Int
+	TicketsSold *big.Int
+	Organizer   common.Address
+	Active      bool
+}
+
+// Listing is the secondary-market view returned by getListing.
+type Listing struct {
+	Price  *big.Int
+	Seller common.Address
+	Active bool
+}
+
+type BuddyEvents struct {
+	address  common.Address
+	contract *bind.BoundContract
+}
+
+func NewBuddyEvents(address common.Address, backend bind.ContractBackend) *BuddyEvents {
+	return &BuddyEvents{
+		address:  address,
+		contract: bind.NewBoundContract(address, buddyEventsABI, backend, backend, backend),
+	}
+}
+
+func (b *BuddyEvents) Address() common.Address {
+	return b.address
+}
+
+// ===== Event management =====
+
+func (b *BuddyEvents) CreateEvent(opts *bind.TransactOpts, name string, priceInUSDC, maxTickets *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "createEvent", name, priceInUSDC, maxTickets)
+}
+
+func (b *BuddyEvents) EditEvent(opts *bind.TransactOpts, eventID *big.Int, name string, priceInUSDC *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "editEvent", eventID, name, priceInUSDC)
+}
+
+func (b *BuddyEvents) CancelEvent(opts *bind.TransactOpts, eventID *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "cancelEvent", eventID)
+}
+
+// ===== Tickets =====
+
+func (b *BuddyEvents) BuyTicket(opts *bind.TransactOpts, eventID *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "buyTicket", eventID)
+}
+
+func (b *BuddyEvents) ListTicket(opts *bind.TransactOpts, tokenID, price *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "listTicket", tokenID, price)
+}
+
+func (b *BuddyEvents) DelistTicket(opts *bind.TransactOpts, tokenID *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "delistTicket", tokenID)
+}
+
+func (b *BuddyEvents) BuyListedTicket(opts *bind.TransactOpts, tokenID *big.Int) (*types.Transaction, error) {
+	return b.contract.Transact(opts, "buyListedTicket", tokenID)
+}
+
+// ===== Views =====
+
+func (b *BuddyEvents) GetEvent(opts *bind.CallOpts, eventID *big.Int) (*Event, error) {
+	var evt Event
+	if err := b.call(opts, &evt, "getEvent", eventID); err != nil {
+		return nil, err
+	}
+	return &evt, nil
+}
+
+func (b *BuddyEvents) GetListing(opts *bind.CallOpts, tokenID *big.Int) (*Listing, error) {
+	var listing Listing
+	if err := b.call(opts, &listing, "getListing", tokenID); err != nil {
+		return nil, err
+	}
+	return &listing, nil
+}
+
+// ===== Logs =====
+
+// TicketPurchased is emitted by buyTicket. Field names follow the ABI so the
+// log can be unpacked by name.
+type TicketPurchased struct {
+	EventId *big.Int
+	TokenId *big.Int
+	Buyer   common.Address
+	Price   *big.Int
+}
+
+// TicketListed is emitted by listTicket.
+type TicketListed struct {
+	TokenId *big.Int
+	Price   *big.Int
+	Seller  common.Address
+}
+
+// TicketSold is emitted by buyListedTicket.
+type TicketSold struct {
+	TokenId *big.Int
+	Price   *big.Int
+	Seller  common.Address
+	Buyer   common.Address
+}
+
+// EventCreated is emitted by createEvent.
+type EventCreated struct {
+	EventId    *big.Int
+	Name       string
+	Price      *big.Int
+	MaxTickets *big.Int
+	Organizer  common.Address
+}
+
+// EventUpdated is emitted by editEvent.
+type EventUpdated struct {
+	EventId *big.Int
+	Name    string
+	Price   *big.Int
+}
+
+func (b *BuddyEvents) TicketPurchasedLog(receipt *types.Receipt) (*TicketPurchased, error) {
+	var out TicketPurchased
+	return &out, b.findLog(receipt, "TicketPurchased", &out)
+}
+
+func (b *BuddyEvents) TicketListedLog(receipt *types.Receipt) (*TicketListed, error) {
+	var out TicketListed
+	return &out, b.findLog(receipt, "TicketListed", &out)
+}
+
+func (b *BuddyEvents) TicketSoldLog(receipt *types.Receipt) (*TicketSold, error) {
+	var out TicketSold
+	return &out, b.findLog(receipt, "TicketSold", &out)
+}
+
+func (b *BuddyEvents) EventCreatedLog(receipt *types.Receipt) (*EventCreated, error) {
+	var out EventCreated
+	return &out, b.findLog(receipt, "EventCreated", &out)
+}
+
+func (b *BuddyEvents) EventUpdatedLog(receipt *types.Receipt) (*EventUpdated, error) {
+	var out EventUpdated
+	return &out, b.findLog(receipt, "EventUpdated", &out)
+}
+
+// findLog unpacks the first log in receipt emitted by this contract as name.
+func (b *BuddyEvents) findLog(receipt *types.Receipt, name string, out any) error {
+	id := buddyEventsABI.Events[name].ID
+	for _, log := range receipt.Logs {
+		if log.Address != b.address || len(log.Topics) == 0 || log.Topics[0] != id {
+			continue
+		}
+		if err := b.contract.UnpackLog(out, name, *log); err != nil {
+			return fmt.Errorf("decode %s log: %w", name, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no %s log in transaction %s", name, receipt.TxHash.Hex())
+}
+
+func (b *BuddyEvents) call(opts *bind.CallOpts, out any, method string, params ...any) error {
+	input, err := buddyEventsABI.Pack(method, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	raw, err := b.contract.CallRaw(opts, input)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if err := buddyEventsABI.UnpackIntoInterface(out, method, raw); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(fmt.Sprintf("chain: invalid ABI: %v", err))
+	}
+	return parsed
+}
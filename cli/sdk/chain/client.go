@@ -0,0 +1,184 @@
+// / cli/sdk/chain/client.go — Monad RPC connection and signing helpers
+// / Wraps go-ethereum's ethclient so commands can sign and send in-process.
+package chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type Client struct {
+	eth     *ethclient.Client
+	chainID *big.Int
+}
+
+// DialOption configures Dial.
+type DialOption func(*dialConfig)
+
+type dialConfig struct {
+	callTimeout time.Duration
+}
+
+// WithCallTimeout bounds every JSON-RPC request to an http(s) endpoint,
+// including those made by contract bindings, on top of any deadline on
+// their ctx. WaitForReceipt still waits as long as its options allow; each
+// poll is bounded. 0, the default, disables it.
+func WithCallTimeout(d time.Duration) DialOption {
+	return func(c *dialConfig) { c.callTimeout = d }
+}
+
+// Dial connects to the RPC endpoint and caches its chain ID for signing.
+func Dial(ctx context.Context, rpcURL string, opts ...DialOption) (*Client, error) {
+	var cfg dialConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var rpcOpts []rpc.ClientOption
+	if cfg.callTimeout > 0 {
+		rpcOpts = append(rpcOpts, rpc.WithHTTPClient(&http.Client{Timeout: cfg.callTimeout}))
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.callTimeout)
+		defer cancel()
+	}
+
+	rpcClient, err := rpc.DialOptions(ctx, rpcURL, rpcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RPC %s: %w", rpcURL, err)
+	}
+	eth := ethclient.NewClient(rpcClient)
+
+	chainID, err := eth.ChainID(ctx)
+	if err != nil {
+		eth.Close()
+		return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	return &Client{eth: eth, chainID: chainID}, nil
+}
+
+func (c *Client) Close() {
+	c.eth.Close()
+}
+
+// Backend exposes the underlying client for contract bindings.
+func (c *Client) Backend() *ethclient.Client {
+	return c.eth
+}
+
+func (c *Client) ChainID() *big.Int {
+	return new(big.Int).Set(c.chainID)
+}
+
+// ErrReverted is returned when a mined transaction has a failed status.
+var ErrReverted = errors.New("transaction reverted")
+
+// ReceiptOptions controls how long WaitForReceipt waits and how deep the
+// transaction must be buried before it is considered final.
+type ReceiptOptions struct {
+	Confirmations uint64        // blocks including the tx block (0 or 1 = inclusion only)
+	Timeout       time.Duration // 0 = wait until ctx is done
+	PollInterval  time.Duration // 0 = one second
+}
+
+// WaitForReceipt polls for the receipt of hash until it has the requested
+// confirmations. A reverted receipt is returned together with ErrReverted.
+func (c *Client) WaitForReceipt(ctx context.Context, hash common.Hash, opts ReceiptOptions) (*types.Receipt, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.confirmedReceipt(ctx, hash, opts.Confirmations)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("%w: %s in block %s", ErrReverted, hash.Hex(), receipt.BlockNumber)
+			}
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("timed out waiting for receipt of %s", hash.Hex())
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// confirmedReceipt returns nil without error while hash is pending or not yet
+// buried under enough blocks.
+func (c *Client) confirmedReceipt(ctx context.Context, hash common.Hash, confirmations uint64) (*types.Receipt, error) {
+	receipt, err := c.eth.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch receipt: %w", err)
+	}
+	if confirmations <= 1 {
+		return receipt, nil
+	}
+
+	head, err := c.eth.BlockNumber(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch block number: %w", err)
+	}
+	if head+1 < receipt.BlockNumber.Uint64()+confirmations {
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+// ParsePrivateKey decodes a hex private key with or without the 0x prefix.
+func ParsePrivateKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
+
+// ParseAddress validates a hex address before it is used in a call.
+func ParseAddress(label, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid %s address: %q", label, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+// AddressOf returns the account address controlled by key.
+func AddressOf(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
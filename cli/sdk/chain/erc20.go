@@ -0,0 +1,68 @@
+// / cli/sdk/chain/erc20.go — Minimal ERC-20 bindings (USDC)
+package chain
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const ERC20ABI = `[
+  {"type":"function","name":"approve","stateMutability":"nonpayable",
+   "inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],
+   "outputs":[{"name":"","type":"bool"}]},
+  {"type":"function","name":"transfer","stateMutability":"nonpayable",
+   "inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],
+   "outputs":[{"name":"","type":"bool"}]},
+  {"type":"function","name":"allowance","stateMutability":"view",
+   "inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],
+   "outputs":[{"name":"","type":"uint256"}]},
+  {"type":"function","name":"balanceOf","stateMutability":"view",
+   "inputs":[{"name":"account","type":"address"}],
+   "outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var erc20ABI = mustParseABI(ERC20ABI)
+
+type ERC20 struct {
+	address  common.Address
+	contract *bind.BoundContract
+}
+
+func NewERC20(address common.Address, backend bind.ContractBackend) *ERC20 {
+	return &ERC20{
+		address:  address,
+		contract: bind.NewBoundContract(address, erc20ABI, backend, backend, backend),
+	}
+}
+
+func (t *ERC20) Address() common.Address {
+	return t.address
+}
+
+func (t *ERC20) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.Transact(opts, "approve", spender, amount)
+}
+
+func (t *ERC20) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.contract.Transact(opts, "transfer", to, amount)
+}
+
+func (t *ERC20) Allowance(opts *bind.CallOpts, owner, spender common.Address) (*big.Int, error) {
+	return t.callUint(opts, "allowance", owner, spender)
+}
+
+func (t *ERC20) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	return t.callUint(opts, "balanceOf", account)
+}
+
+func (t *ERC20) callUint(opts *bind.CallOpts, method string, params ...any) (*big.Int, error) {
+	var out []any
+	if err := t.contract.Call(opts, &out, method, params...); err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
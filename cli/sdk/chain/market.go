@@ -0,0 +1,262 @@
+// / cli/sdk/chain/market.go — End-to-end ticket operations on a deployment
+// / Approve + buyTicket, listTicket, createEvent and editEvent, each waited on and decoded.
+package chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrEventInactive = errors.New("event is not active")
+	ErrSoldOut       = errors.New("event is sold out")
+	ErrNotOrganizer  = errors.New("signer is not the event organizer")
+	ErrPriceLocked   = errors.New("price is locked after the first sale")
+)
+
+// USDCDecimals is the precision of USDC amounts on-chain.
+const USDCDecimals = 6
+
+// Market pairs a BuddyEvents deployment with the token it is paid in.
+type Market struct {
+	client *Client
+	events *BuddyEvents
+	usdc   *ERC20
+}
+
+func NewMarket(client *Client, contract, usdc common.Address) *Market {
+	return &Market{
+		client: client,
+		events: NewBuddyEvents(contract, client.Backend()),
+		usdc:   NewERC20(usdc, client.Backend()),
+	}
+}
+
+// Contract exposes the raw BuddyEvents bindings.
+func (m *Market) Contract() *BuddyEvents {
+	return m.events
+}
+
+// USDC exposes the payment token bindings.
+func (m *Market) USDC() *ERC20 {
+	return m.usdc
+}
+
+// TxOption configures a Market operation.
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	fees          FeeOptions
+	receipt       ReceiptOptions
+	exactApproval bool
+	logf          func(format string, args ...any)
+}
+
+// WithFees overrides gas limit and fee selection.
+func WithFees(fees FeeOptions) TxOption {
+	return func(c *txConfig) { c.fees = fees }
+}
+
+// WithReceiptOptions sets confirmations and timeout for every wait.
+func WithReceiptOptions(opts ReceiptOptions) TxOption {
+	return func(c *txConfig) { c.receipt = opts }
+}
+
+// WithExactApproval resets a larger standing allowance down to the amount
+// being spent instead of reusing it.
+func WithExactApproval(exact bool) TxOption {
+	return func(c *txConfig) { c.exactApproval = exact }
+}
+
+// WithLogger receives one line per step (approvals, sent hashes, waits).
+func WithLogger(logf func(format string, args ...any)) TxOption {
+	return func(c *txConfig) { c.logf = logf }
+}
+
+func newTxConfig(opts []TxOption) *txConfig {
+	c := &txConfig{logf: func(string, ...any) {}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Purchase is the outcome of BuyTicket.
+type Purchase struct {
+	Event   *Event
+	Ticket  *TicketPurchased
+	Receipt *types.Receipt
+}
+
+// BuyTicket checks the event is on sale, approves the USDC shortfall, buys a
+// ticket and waits for the receipt.
+func (m *Market) BuyTicket(ctx context.Context, key *ecdsa.PrivateKey, eventID *big.Int, opts ...TxOption) (*Purchase, error) {
+	cfg := newTxConfig(opts)
+
+	evt, err := m.events.GetEvent(&bind.CallOpts{Context: ctx}, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	cfg.logf("Event: %s (price %s USDC, sold %s/%s)", evt.Name, FormatUnits(evt.PriceInUSDC, USDCDecimals), evt.TicketsSold, evt.MaxTickets)
+	if !evt.Active {
+		return nil, fmt.Errorf("event %s: %w", eventID, ErrEventInactive)
+	}
+	if evt.TicketsSold.Cmp(evt.MaxTickets) >= 0 {
+		return nil, fmt.Errorf("event %s: %w", eventID, ErrSoldOut)
+	}
+
+	if err := m.ensureAllowance(ctx, key, evt.PriceInUSDC, cfg); err != nil {
+		return nil, fmt.Errorf("USDC approve failed: %w", err)
+	}
+
+	cfg.logf("Buying ticket...")
+	receipt, err := m.send(ctx, key, cfg, "Buy", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.events.BuyTicket(opts, eventID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("buy ticket failed: %w", err)
+	}
+	purchased, err := m.events.TicketPurchasedLog(receipt)
+	if err != nil {
+		return nil, fmt.Errorf("ticket purchase mined but not decoded: %w", err)
+	}
+	return &Purchase{Event: evt, Ticket: purchased, Receipt: receipt}, nil
+}
+
+// ListTicket lists tokenID for resale at price (USDC units) and waits for
+// the receipt.
+func (m *Market) ListTicket(ctx context.Context, key *ecdsa.PrivateKey, tokenID, price *big.Int, opts ...TxOption) (*TicketListed, *types.Receipt, error) {
+	cfg := newTxConfig(opts)
+	receipt, err := m.send(ctx, key, cfg, "List", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.events.ListTicket(opts, tokenID, price)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list ticket failed: %w", err)
+	}
+	listed, err := m.events.TicketListedLog(receipt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing mined but not decoded: %w", err)
+	}
+	return listed, receipt, nil
+}
+
+// CreateEvent deploys an event with the signer as organizer and waits for
+// the receipt. price is in USDC units.
+func (m *Market) CreateEvent(ctx context.Context, key *ecdsa.PrivateKey, name string, price, maxTickets *big.Int, opts ...TxOption) (*EventCreated, *types.Receipt, error) {
+	cfg := newTxConfig(opts)
+	cfg.logf("Creating event %q on-chain (price %s USDC, %s tickets)...", name, FormatUnits(price, USDCDecimals), maxTickets)
+	receipt, err := m.send(ctx, key, cfg, "Create", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.events.CreateEvent(opts, name, price, maxTickets)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create event failed: %w", err)
+	}
+	created, err := m.events.EventCreatedLog(receipt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("event creation mined but not decoded: %w", err)
+	}
+	return created, receipt, nil
+}
+
+// EditEvent renames and/or reprices eventID as its organizer and waits for
+// the receipt. An empty name or nil price keeps the current value. It checks
+// the contract's rules first: the event must be active, key must be the
+// organizer, and the price cannot change once a ticket is sold.
+func (m *Market) EditEvent(ctx context.Context, key *ecdsa.PrivateKey, eventID *big.Int, name string, price *big.Int, opts ...TxOption) (*EventUpdated, *types.Receipt, error) {
+	cfg := newTxConfig(opts)
+
+	evt, err := m.events.GetEvent(&bind.CallOpts{Context: ctx}, eventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if !evt.Active {
+		return nil, nil, fmt.Errorf("event %s: %w", eventID, ErrEventInactive)
+	}
+	if evt.Organizer != AddressOf(key) {
+		return nil, nil, fmt.Errorf("event %s is organized by %s: %w", eventID, evt.Organizer.Hex(), ErrNotOrganizer)
+	}
+	if price == nil {
+		price = evt.PriceInUSDC
+	}
+	if evt.TicketsSold.Sign() > 0 && price.Cmp(evt.PriceInUSDC) != 0 {
+		return nil, nil, fmt.Errorf("event %s has sold %s tickets: %w", eventID, evt.TicketsSold, ErrPriceLocked)
+	}
+
+	cfg.logf("Editing event %s on-chain (name %q, price %s USDC)...", eventID, name, FormatUnits(price, USDCDecimals))
+	receipt, err := m.send(ctx, key, cfg, "Edit", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.events.EditEvent(opts, eventID, name, price)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("edit event failed: %w", err)
+	}
+	updated, err := m.events.EventUpdatedLog(receipt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("edit mined but not decoded: %w", err)
+	}
+	return updated, receipt, nil
+}
+
+// EnsureAllowance makes sure the contract may pull amount USDC from the
+// signer, sending approve only when needed (see WithExactApproval).
+func (m *Market) EnsureAllowance(ctx context.Context, key *ecdsa.PrivateKey, amount *big.Int, opts ...TxOption) error {
+	return m.ensureAllowance(ctx, key, amount, newTxConfig(opts))
+}
+
+func (m *Market) ensureAllowance(ctx context.Context, key *ecdsa.PrivateKey, amount *big.Int, cfg *txConfig) error {
+	if amount.Sign() == 0 && !cfg.exactApproval {
+		return nil
+	}
+
+	spender := m.events.Address()
+	current, err := m.usdc.Allowance(&bind.CallOpts{Context: ctx}, AddressOf(key), spender)
+	if err != nil {
+		return fmt.Errorf("failed to read allowance: %w", err)
+	}
+
+	usdc := func(v *big.Int) string { return FormatUnits(v, USDCDecimals) }
+	switch cmp := current.Cmp(amount); {
+	case cmp == 0, cmp > 0 && !cfg.exactApproval:
+		cfg.logf("USDC allowance %s already covers %s, skipping approve", usdc(current), usdc(amount))
+		return nil
+	case cmp < 0:
+		cfg.logf("Approving USDC (allowance %s, shortfall %s)...", usdc(current), usdc(new(big.Int).Sub(amount, current)))
+	default:
+		cfg.logf("Resetting USDC allowance from %s to %s...", usdc(current), usdc(amount))
+	}
+
+	_, err = m.send(ctx, key, cfg, "Approve", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.usdc.Approve(opts, spender, amount)
+	})
+	return err
+}
+
+// send builds, signs and submits one transaction, then waits for it.
+func (m *Market) send(ctx context.Context, key *ecdsa.PrivateKey, cfg *txConfig, label string, fn func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+	opts, err := m.client.Transactor(ctx, key, cfg.fees)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := fn(opts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.logf("%s tx: %s", label, tx.Hash().Hex())
+	if cfg.receipt.Confirmations > 1 {
+		cfg.logf("Waiting for %d confirmations...", cfg.receipt.Confirmations)
+	}
+	return m.client.WaitForReceipt(ctx, tx.Hash(), cfg.receipt)
+}
+
+// FormatUnits renders a token amount with the given decimals, e.g.
+// FormatUnits(1500000, 6) = "1.500000".
+func FormatUnits(v *big.Int, decimals int) string {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	return new(big.Float).Quo(new(big.Float).SetInt(v), scale).Text('f', decimals)
+}
@@ -0,0 +1,42 @@
+// / cli/sdk/chain/sign.go — EIP-191 personal message signing
+// / Used to prove wallet ownership to the BuddyEvents API.
+package chain
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignMessage signs message the way personal_sign does and returns the
+// 65-byte signature as 0x-prefixed hex with V in {27, 28}.
+func SignMessage(key *ecdsa.PrivateKey, message string) (string, error) {
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig), nil
+}
+
+// ClaimMessage is what a buyer signs to claim the off-chain ticket for a
+// direct buyTicket transaction. The API accepts a claim once, and only
+// within minutes of issuedAt. Must match onChainPurchaseClaimMessage in
+// lib/monad.ts.
+func ClaimMessage(txHash common.Hash, tokenID *big.Int, issuedAt time.Time) string {
+	return fmt.Sprintf("BuddyEvents on-chain purchase\nTx: %s\nToken: %s\nIssued At: %s",
+		strings.ToLower(txHash.Hex()), tokenID.String(), ClaimTime(issuedAt))
+}
+
+// ClaimTime formats issuedAt as ClaimMessage and the API's issuedAt field
+// expect it.
+func ClaimTime(issuedAt time.Time) string {
+	return issuedAt.UTC().Format(time.RFC3339)
+}
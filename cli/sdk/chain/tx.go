@@ -0,0 +1,149 @@
+// / cli/sdk/chain/tx.go — Shared transaction builder
+// / Resolves EIP-1559 fees from fee history (legacy gas price on pre-London chains).
+package chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// feeHistoryBlocks is how far back base fees are sampled for the fee cap.
+const feeHistoryBlocks = 5
+
+// FeeOptions overrides automatic gas and fee selection. Zero values mean
+// "estimate".
+type FeeOptions struct {
+	GasLimit    uint64   // 0 = eth_estimateGas
+	MaxFee      *big.Int // max fee per gas in wei (gas price on legacy chains)
+	PriorityFee *big.Int // max priority fee per gas in wei
+}
+
+// Transactor returns signing options for key with fees already resolved, so
+// every command that signs builds its transactions the same way. Contract
+// bindings estimate the gas limit when fees.GasLimit is zero.
+func (c *Client) Transactor(ctx context.Context, key *ecdsa.PrivateKey, fees FeeOptions) (*bind.TransactOpts, error) {
+	opts := bind.NewKeyedTransactor(key, c.chainID)
+	opts.Context = ctx
+	opts.GasLimit = fees.GasLimit
+
+	head, err := c.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	// Pre-London chain: legacy transactions priced by eth_gasPrice.
+	if head.BaseFee == nil {
+		if fees.PriorityFee != nil {
+			return nil, fmt.Errorf("chain reports no base fee; --priority-fee is not supported")
+		}
+		opts.GasPrice = fees.MaxFee
+		if opts.GasPrice == nil {
+			if opts.GasPrice, err = c.eth.SuggestGasPrice(ctx); err != nil {
+				return nil, fmt.Errorf("failed to fetch gas price: %w", err)
+			}
+		}
+		return opts, nil
+	}
+
+	tip := fees.PriorityFee
+	if tip == nil {
+		if tip, err = c.eth.SuggestGasTipCap(ctx); err != nil {
+			return nil, fmt.Errorf("failed to fetch priority fee: %w", err)
+		}
+	}
+	feeCap := fees.MaxFee
+	if feeCap == nil {
+		baseFee := c.recentBaseFee(ctx, head)
+		feeCap = new(big.Int).Add(tip, new(big.Int).Mul(baseFee, big.NewInt(2)))
+	}
+	if feeCap.Cmp(tip) < 0 {
+		return nil, fmt.Errorf("max fee %s wei is below priority fee %s wei", feeCap, tip)
+	}
+
+	opts.GasTipCap = tip
+	opts.GasFeeCap = feeCap
+	return opts, nil
+}
+
+// recentBaseFee returns the highest base fee over the last few blocks and the
+// projected next block, so the fee cap survives short spikes. RPCs without
+// eth_feeHistory fall back to the latest header.
+func (c *Client) recentBaseFee(ctx context.Context, head *types.Header) *big.Int {
+	highest := new(big.Int).Set(head.BaseFee)
+	history, err := c.eth.FeeHistory(ctx, feeHistoryBlocks, nil, nil)
+	if err != nil {
+		return highest
+	}
+	for _, fee := range history.BaseFee {
+		if fee != nil && fee.Cmp(highest) > 0 {
+			highest = fee
+		}
+	}
+	return highest
+}
+
+// TransferValue sends native MON to `to` using fees resolved by Transactor.
+func (c *Client) TransferValue(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		estimate, err := c.eth.EstimateGas(ctx, ethereum.CallMsg{
+			From:      opts.From,
+			To:        &to,
+			Value:     value,
+			GasPrice:  opts.GasPrice,
+			GasTipCap: opts.GasTipCap,
+			GasFeeCap: opts.GasFeeCap,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		gasLimit = estimate
+	}
+
+	nonce, err := c.eth.PendingNonceAt(ctx, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce: %w", err)
+	}
+
+	var tx *types.Transaction
+	if opts.GasPrice != nil {
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       &to,
+			Value:    value,
+			Gas:      gasLimit,
+			GasPrice: opts.GasPrice,
+		})
+	} else {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.chainID,
+			Nonce:     nonce,
+			To:        &to,
+			Value:     value,
+			Gas:       gasLimit,
+			GasTipCap: opts.GasTipCap,
+			GasFeeCap: opts.GasFeeCap,
+		})
+	}
+
+	signed, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := c.eth.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,492 @@
+/// cli/sdk/client.go — HTTP client for BuddyEvents API
+/// Calls Next.js API routes and Convex functions
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	tokens     TokenSource // nil sends no Authorization header
+	timeout    time.Duration
+	retry      RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to add a transport.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// WithTimeout bounds each client call, retries and reading the response
+// included, on top of any deadline on ctx. Default 30s; 0 disables it.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// WithRetry replaces DefaultRetryPolicy for safe (GET/HEAD) requests.
+// Requests with side effects are never retried.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// NewClient returns a client for the BuddyEvents deployment at baseURL,
+// e.g. "https://buddyevents.example" or "http://localhost:3000".
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		userAgent:  "buddyevents-sdk/" + Version,
+		timeout:    30 * time.Second,
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL is the deployment the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// ===== Events =====
+
+// ListEvents returns events, optionally filtered by status.
+func (c *Client) ListEvents(ctx context.Context, status EventStatus) ([]Event, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	var result struct {
+		Events []Event `json:"events"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/events", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// EventSort orders SearchEvents results.
+type EventSort string
+
+const (
+	SortCreated    EventSort = "created"    // newest first (the default)
+	SortStart      EventSort = "start"      // soonest first
+	SortPrice      EventSort = "price"      // cheapest first
+	SortPopularity EventSort = "popularity" // most tickets sold first
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// EventQuery filters, sorts and pages SearchEvents. Zero fields do not
+// filter.
+type EventQuery struct {
+	Statuses         []EventStatus
+	ModerationStatus ModerationStatus // "" for every moderation state
+	TeamID           string
+	ProjectID        string
+	From             time.Time // events that end after From
+	To               time.Time // events that start before To
+	MaxPrice         *float64  // USDC
+	HasSeats         bool
+	Location         string // case-insensitive substring
+	Text             string // every word must appear in the name or description
+	Sort             EventSort
+	Order            SortOrder // "" for the sort's natural order
+	Limit            int       // page size; 0 for all events
+	Cursor           string
+}
+
+// EventPage is one page of SearchEvents. NextCursor is empty on the last
+// page.
+type EventPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// SearchEvents returns the events matching q, filtered and sorted by the API.
+func (c *Client) SearchEvents(ctx context.Context, q EventQuery) (*EventPage, error) {
+	query := url.Values{}
+	if len(q.Statuses) > 0 {
+		statuses := make([]string, len(q.Statuses))
+		for i, s := range q.Statuses {
+			statuses[i] = string(s)
+		}
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	set("moderationStatus", string(q.ModerationStatus))
+	set("team", q.TeamID)
+	set("project", q.ProjectID)
+	if !q.From.IsZero() {
+		query.Set("from", strconv.FormatInt(q.From.UnixMilli(), 10))
+	}
+	if !q.To.IsZero() {
+		query.Set("to", strconv.FormatInt(q.To.UnixMilli(), 10))
+	}
+	if q.MaxPrice != nil {
+		query.Set("maxPrice", strconv.FormatFloat(*q.MaxPrice, 'f', -1, 64))
+	}
+	if q.HasSeats {
+		query.Set("hasSeats", "true")
+	}
+	set("location", q.Location)
+	set("q", q.Text)
+	set("sort", string(q.Sort))
+	set("order", string(q.Order))
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	set("cursor", q.Cursor)
+
+	var page EventPage
+	if err := c.do(ctx, http.MethodGet, "/api/events", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetEvent returns one event with its team, project and sponsors.
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*EventDetail, error) {
+	var detail EventDetail
+	if err := c.do(ctx, http.MethodGet, "/api/events/"+url.PathEscape(eventID), nil, nil, &detail); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+type CreateEventRequest struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	StartTime      int64    `json:"startTime"`
+	EndTime        int64    `json:"endTime"`
+	Price          float64  `json:"price"`
+	MaxTickets     int      `json:"maxTickets"`
+	TeamID         string   `json:"teamId"`
+	ProjectID      string   `json:"projectId,omitempty"`
+	Sponsors       []string `json:"sponsors,omitempty"` // sponsor IDs
+	Location       string   `json:"location"`
+	CreatorAddress string   `json:"creatorAddress"`
+}
+
+// CreateEvent creates an event and returns its Convex ID. Requires admin.
+func (c *Client) CreateEvent(ctx context.Context, req CreateEventRequest) (string, error) {
+	var result struct {
+		EventID string `json:"eventId"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/events", nil, req, &result); err != nil {
+		return "", err
+	}
+	return result.EventID, nil
+}
+
+// EditEventRequest holds the fields to change; nil fields are left as they
+// are.
+type EditEventRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	StartTime   *int64    `json:"startTime,omitempty"`
+	EndTime     *int64    `json:"endTime,omitempty"`
+	Price       *float64  `json:"price,omitempty"` // USDC, human-readable
+	MaxTickets  *int      `json:"maxTickets,omitempty"`
+	Location    *string   `json:"location,omitempty"`
+	TeamID      *string   `json:"teamId,omitempty"`
+	ProjectID   *string   `json:"projectId,omitempty"`
+	Sponsors    *[]string `json:"sponsors,omitempty"` // sponsor IDs; an empty list removes all
+}
+
+// EditEvent updates an event in Convex. Requires admin. It does not touch
+// the contract; see chain.Market.EditEvent.
+func (c *Client) EditEvent(ctx context.Context, eventID string, req EditEventRequest) error {
+	body := struct {
+		Action  string `json:"action"`
+		EventID string `json:"eventId"`
+		EditEventRequest
+	}{"edit", eventID, req}
+	return c.do(ctx, http.MethodPost, "/api/events", nil, body, nil)
+}
+
+// LinkOnChain records that eventID was deployed as onChainEventID on the
+// BuddyEvents contract at contractAddress. Requires admin.
+func (c *Client) LinkOnChain(ctx context.Context, eventID string, onChainEventID int64, contractAddress string) error {
+	return c.do(ctx, http.MethodPost, "/api/events", nil, map[string]interface{}{
+		"action":          "link",
+		"eventId":         eventID,
+		"onChainEventId":  onChainEventID,
+		"contractAddress": contractAddress,
+	}, nil)
+}
+
+// CancelEvent cancels an event. Requires admin.
+func (c *Client) CancelEvent(ctx context.Context, eventID string) error {
+	return c.do(ctx, http.MethodPost, "/api/events", nil, map[string]interface{}{
+		"action":  "cancel",
+		"eventId": eventID,
+	}, nil)
+}
+
+// ===== Tickets =====
+
+// ListTicketsByEvent returns every ticket for an event. Requires admin.
+func (c *Client) ListTicketsByEvent(ctx context.Context, eventID string) ([]Ticket, error) {
+	return c.listTickets(ctx, url.Values{"tickets": {"true"}, "eventId": {eventID}})
+}
+
+// ListTicketsByBuyer returns the tickets held by buyerAddress.
+func (c *Client) ListTicketsByBuyer(ctx context.Context, buyerAddress string) ([]Ticket, error) {
+	return c.listTickets(ctx, url.Values{"tickets": {"true"}, "buyer": {buyerAddress}})
+}
+
+func (c *Client) listTickets(ctx context.Context, query url.Values) ([]Ticket, error) {
+	var result struct {
+		Tickets []Ticket `json:"tickets"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/events", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Tickets, nil
+}
+
+type RecordPurchaseRequest struct {
+	TxHash       string `json:"txHash"`
+	TokenID      string `json:"tokenId"`
+	BuyerAddress string `json:"buyerAddress"`
+	Signature    string `json:"signature"`
+	IssuedAt     string `json:"issuedAt"` // chain.ClaimTime of the signed message
+	EventID      string `json:"eventId,omitempty"`
+	AgentID      string `json:"agentId,omitempty"`
+}
+
+type RecordPurchaseResponse struct {
+	Success         bool   `json:"success"`
+	TicketID        string `json:"ticketId"`
+	QRCode          string `json:"qrCode"`
+	EventID         string `json:"eventId"`
+	Buyer           string `json:"buyer"`
+	TokenID         string `json:"tokenId"`
+	TxHash          string `json:"txHash"`
+	AlreadyRecorded bool   `json:"alreadyRecorded"`
+	Message         string `json:"message"`
+}
+
+// RecordOnChainPurchase registers a confirmed buyTicket transaction so the
+// buyer gets the same off-chain ticket and QR as web and x402 purchases.
+func (c *Client) RecordOnChainPurchase(ctx context.Context, req RecordPurchaseRequest) (*RecordPurchaseResponse, error) {
+	var result RecordPurchaseResponse
+	if err := c.do(ctx, http.MethodPost, "/api/tickets/record", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ClaimPurchase signs a fresh claim for a decoded TicketPurchased log with
+// the buyer's key and records it. The key must still own the token. eventID
+// may be empty to let the API resolve the event from the on-chain ID.
+func (c *Client) ClaimPurchase(ctx context.Context, key *ecdsa.PrivateKey, txHash common.Hash, purchased *chain.TicketPurchased, eventID string) (*RecordPurchaseResponse, error) {
+	issuedAt := time.Now()
+	signature, err := chain.SignMessage(key, chain.ClaimMessage(txHash, purchased.TokenId, issuedAt))
+	if err != nil {
+		return nil, err
+	}
+	return c.RecordOnChainPurchase(ctx, RecordPurchaseRequest{
+		TxHash:       txHash.Hex(),
+		TokenID:      purchased.TokenId.String(),
+		BuyerAddress: purchased.Buyer.Hex(),
+		Signature:    signature,
+		IssuedAt:     chain.ClaimTime(issuedAt),
+		EventID:      eventID,
+	})
+}
+
+// ===== Teams, projects, sponsors =====
+
+// CreateTeam creates a team and returns its Convex ID.
+func (c *Client) CreateTeam(ctx context.Context, name, description, walletAddress string, members []string) (string, error) {
+	var result struct {
+		TeamID string `json:"teamId"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/teams", nil, map[string]interface{}{
+		"name":          name,
+		"description":   description,
+		"walletAddress": walletAddress,
+		"members":       members,
+	}, &result); err != nil {
+		return "", err
+	}
+	return result.TeamID, nil
+}
+
+// ListTeams returns every team.
+func (c *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	var result struct {
+		Teams []Team `json:"teams"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/teams", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Teams, nil
+}
+
+// ListProjects returns every project.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	var result struct {
+		Projects []Project `json:"projects"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/projects", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Projects, nil
+}
+
+// ListSponsors returns every sponsor.
+func (c *Client) ListSponsors(ctx context.Context) ([]Sponsor, error) {
+	var result struct {
+		Sponsors []Sponsor `json:"sponsors"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/sponsors", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Sponsors, nil
+}
+
+// ===== Agents =====
+
+// RegisterAgent registers an agent wallet under a human owner and returns
+// the agent ID.
+func (c *Client) RegisterAgent(ctx context.Context, name, walletAddress, ownerAddress string) (string, error) {
+	var result struct {
+		AgentID string `json:"agentId"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/agent", nil, map[string]interface{}{
+		"name":          name,
+		"walletAddress": walletAddress,
+		"ownerAddress":  ownerAddress,
+	}, &result); err != nil {
+		return "", err
+	}
+	return result.AgentID, nil
+}
+
+// GetAgent looks up the agent registered for walletAddress.
+func (c *Client) GetAgent(ctx context.Context, walletAddress string) (*Agent, error) {
+	var result struct {
+		Agent *Agent `json:"agent"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/agent", url.Values{"wallet": {walletAddress}}, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Agent == nil {
+		return nil, fmt.Errorf("invalid API response: missing agent")
+	}
+	return result.Agent, nil
+}
+
+// ===== HTTP helpers =====
+
+// do sends a JSON request and decodes the response into out (if non-nil).
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	token := ""
+	if c.tokens != nil {
+		var err error
+		if token, err = c.tokens.Token(ctx); err != nil {
+			return err
+		}
+	}
+
+	send := func() (*http.Response, error) {
+		var reader io.Reader
+		if data != nil {
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("User-Agent", c.userAgent)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return c.httpClient.Do(req)
+	}
+
+	policy := NoRetry
+	if safeMethod(method) {
+		policy = c.retry
+	}
+	resp, err := policy.Do(ctx, send)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return apiError(resp.StatusCode, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("invalid API response: %s", string(raw))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// / cli/sdk/doc.go — Package documentation and SDK version
+// / The CLI under cli/cmd is a thin wrapper over these packages.
+
+// Package sdk is the Go SDK for BuddyEvents: the REST client for the
+// Next.js API (events, tickets, teams, agents) and the typed models it
+// returns. It is imported as github.com/OxFrancesco/BuddyEvents/cli/sdk. Related
+// packages, under the same path:
+//
+//   - sdk/chain: Monad RPC client, BuddyEvents and ERC-20
+//     bindings, the fee-aware transaction builder, and Market, which runs
+//     approve + buyTicket / listTicket end to end.
+//   - sdk/x402: ticket purchases through the x402 payment flow.
+//   - sdk/wallet: encrypted keystores and BIP-39 HD accounts.
+//
+// Every network call takes a context.Context; constructors take functional
+// options. Listing events:
+//
+//	client := sdk.NewClient("http://localhost:3000")
+//	events, err := client.ListEvents(ctx, sdk.EventActive)
+//
+// Buying a ticket on-chain and claiming its QR code:
+//
+//	rpc, err := chain.Dial(ctx, "https://testnet-rpc.monad.xyz")
+//	market := chain.NewMarket(rpc, contract, usdc)
+//	purchase, err := market.BuyTicket(ctx, key, big.NewInt(1))
+//	ticket, err := client.ClaimPurchase(ctx, key, purchase.Receipt.TxHash, purchase.Ticket, "")
+//
+// See README.md in this directory for the versioned API reference and
+// cli/sdk/examples for complete programs.
+package sdk
+
+// Version is the SDK API version. It follows semver: exported identifiers
+// in sdk/... only change incompatibly on a major bump.
+const Version = "0.10.0"
@@ -0,0 +1,140 @@
+// / cli/sdk/errors.go — Typed API errors with stable machine codes
+// / Lets agents branch on Code instead of matching error strings.
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized matches errors for a missing, invalid or expired token.
+	ErrUnauthorized = errors.New("not authenticated")
+	// ErrForbidden matches errors for callers lacking the role for the call.
+	ErrForbidden = errors.New("not permitted")
+)
+
+// ErrorCode classifies an API error. The values are stable and documented;
+// new codes may be added in minor versions.
+type ErrorCode string
+
+const (
+	CodeUnauthorized    ErrorCode = "unauthorized"     // no valid token; log in
+	CodeForbidden       ErrorCode = "forbidden"        // signed in, but not allowed
+	CodeAdminRequired   ErrorCode = "admin_required"   // the call needs the admin role
+	CodeNotFound        ErrorCode = "not_found"        // event, ticket, agent, ... does not exist
+	CodeSoldOut         ErrorCode = "sold_out"         // no tickets left
+	CodeEventInactive   ErrorCode = "event_inactive"   // event is not on sale
+	CodeConflict        ErrorCode = "conflict"         // already registered / recorded / linked
+	CodeInvalidRequest  ErrorCode = "invalid_request"  // bad or missing parameters
+	CodePaymentRequired ErrorCode = "payment_required" // x402 payment missing or rejected
+	CodeRateLimited     ErrorCode = "rate_limited"     // HTTP 429 after retries
+	CodeServerError     ErrorCode = "server_error"     // the API failed
+	CodeUnknown         ErrorCode = "unknown"
+)
+
+// Error is returned for every API response with status >= 400.
+type Error struct {
+	Status  int       // HTTP status
+	Code    ErrorCode // machine-readable classification
+	Message string    // the response's "error" field, or its raw body
+	Hint    string    // the response's "hint" field, if any
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.Status, e.Message)
+}
+
+// Is lets errors.Is match ErrUnauthorized and ErrForbidden.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.Code == CodeUnauthorized
+	case ErrForbidden:
+		return e.Code == CodeForbidden || e.Code == CodeAdminRequired
+	}
+	return false
+}
+
+// messageCodes refine a 4xx status by the messages routes and Convex
+// functions use, checked in order. Convex wraps messages in request details,
+// so these are substrings. They only matter for deployments that predate the
+// "code" field in error bodies.
+var messageCodes = []struct {
+	substr string
+	code   ErrorCode
+}{
+	{"authentication required", CodeUnauthorized},
+	{"admin access required", CodeAdminRequired},
+	{"sold out", CodeSoldOut},
+	{"not active", CodeEventInactive},
+	{"not found", CodeNotFound},
+	{"already ", CodeConflict},
+	{"forbidden", CodeForbidden},
+	{"does not match", CodeForbidden},
+	{"do not own", CodeForbidden},
+}
+
+// NewError classifies an error response from its status and message, for
+// endpoints whose bodies apiError does not parse (e.g. the x402 purchase).
+func NewError(status int, message string) *Error {
+	return &Error{Status: status, Message: message, Code: classify(status, message)}
+}
+
+// apiError builds the Error for a response. A "code" field in the body wins
+// over classify.
+func apiError(status int, raw []byte) error {
+	var body struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+		Hint  string `json:"hint"`
+	}
+	e := &Error{Status: status, Message: strings.TrimSpace(string(raw))}
+	if json.Unmarshal(raw, &body) == nil {
+		if body.Error != "" {
+			e.Message = body.Error
+		}
+		e.Hint = body.Hint
+	}
+	e.Code = ErrorCode(body.Code)
+	if e.Code == "" {
+		e.Code = classify(status, e.Message)
+	}
+	return e
+}
+
+// classify is the fallback when a response has no "code": the status class
+// decides first, and within 4xx the message may refine the code. A 5xx is
+// always a server error, whatever its message says.
+func classify(status int, message string) ErrorCode {
+	switch {
+	case status >= 500:
+		return CodeServerError
+	case status < 400:
+		return CodeUnknown
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusPaymentRequired:
+		return CodePaymentRequired
+	case status == http.StatusTooManyRequests:
+		return CodeRateLimited
+	}
+	msg := strings.ToLower(message)
+	for _, mc := range messageCodes {
+		if strings.Contains(msg, mc.substr) {
+			return mc.code
+		}
+	}
+	switch status {
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	}
+	return CodeInvalidRequest
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   ErrorCode
+	}{
+		{"body code wins", 500, `{"error":"Uncaught Error: Sold out","code":"sold_out"}`, CodeSoldOut},
+		{"body code on 403", 403, `{"error":"Admin access required","code":"admin_required"}`, CodeAdminRequired},
+		{"5xx ignores message", 500, `{"error":"Uncaught Error: Sold out"}`, CodeServerError},
+		{"5xx not found", 502, `{"error":"Event not found"}`, CodeServerError},
+		{"401 ignores message", 401, `{"error":"Event not found"}`, CodeUnauthorized},
+		{"402", 402, `{"error":"Payment required"}`, CodePaymentRequired},
+		{"429", 429, `{"error":"Too many sign-in requests"}`, CodeRateLimited},
+		{"403 refined", 403, `{"error":"Admin access required"}`, CodeAdminRequired},
+		{"403 default", 403, `{"error":"nope"}`, CodeForbidden},
+		{"400 sold out", 400, `{"error":"Uncaught Error: Sold out"}`, CodeSoldOut},
+		{"400 inactive", 400, `{"error":"Event not active"}`, CodeEventInactive},
+		{"400 already", 400, `{"error":"Agent already registered with this wallet"}`, CodeConflict},
+		{"400 default", 400, `{"error":"limit must be a number"}`, CodeInvalidRequest},
+		{"404 default", 404, `{"error":"gone"}`, CodeNotFound},
+		{"409 default", 409, `{"error":"duplicate"}`, CodeConflict},
+		{"raw body", 400, `Bad Request`, CodeInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *Error
+			if !errors.As(apiError(tt.status, []byte(tt.body)), &e) {
+				t.Fatal("not an *Error")
+			}
+			if e.Code != tt.want {
+				t.Errorf("code = %s, want %s", e.Code, tt.want)
+			}
+		})
+	}
+}
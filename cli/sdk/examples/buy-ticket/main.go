@@ -0,0 +1,63 @@
+// / cli/sdk/examples/buy-ticket/main.go — Buy a ticket on-chain and claim its QR
+// / Usage: BUDDYEVENTS_PRIVATE_KEY=0x... go run ./sdk/examples/buy-ticket <on-chain-event-id>
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk/chain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	apiURL   = "http://localhost:3000"
+	rpcURL   = "https://testnet-rpc.monad.xyz"
+	contract = "0x0000000000000000000000000000000000000000" // your BuddyEvents deployment
+	usdc     = "0x534b2f3A21130d7a60830c2Df862319e593943A3" // Monad testnet USDC
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("usage: buy-ticket <on-chain-event-id>")
+	}
+	eventID, ok := new(big.Int).SetString(os.Args[1], 10)
+	if !ok {
+		log.Fatalf("invalid event id %q", os.Args[1])
+	}
+	key, err := chain.ParsePrivateKey(os.Getenv("BUDDYEVENTS_PRIVATE_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	rpc, err := chain.Dial(ctx, rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rpc.Close()
+
+	market := chain.NewMarket(rpc, common.HexToAddress(contract), common.HexToAddress(usdc))
+	purchase, err := market.BuyTicket(ctx, key, eventID,
+		chain.WithReceiptOptions(chain.ReceiptOptions{Confirmations: 2}),
+		chain.WithLogger(log.Printf),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("token %s minted in block %s\n", purchase.Ticket.TokenId, purchase.Receipt.BlockNumber)
+
+	ticket, err := sdk.NewClient(apiURL).ClaimPurchase(ctx, key, purchase.Receipt.TxHash, purchase.Ticket, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("ticket %s, QR %s\n", ticket.TicketID, ticket.QRCode)
+}
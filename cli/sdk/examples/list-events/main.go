@@ -0,0 +1,33 @@
+// / cli/sdk/examples/list-events/main.go — List active events with the SDK
+// / Usage: go run ./sdk/examples/list-events [api-url]
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+)
+
+func main() {
+	apiURL := "http://localhost:3000"
+	if len(os.Args) > 1 {
+		apiURL = os.Args[1]
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client := sdk.NewClient(apiURL)
+	events, err := client.ListEvents(ctx, sdk.EventActive)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range events {
+		fmt.Printf("%s  %-30s  %6.2f USDC  %d left  %s\n",
+			e.ID, e.Name, e.Price, e.TicketsLeft(), e.StartTime.Time().Format(time.RFC1123))
+	}
+}
@@ -0,0 +1,128 @@
+// / cli/sdk/stream.go — Live event snapshots over server-sent events
+// / GET /api/events/stream pushes the whole event list whenever Convex reports a change.
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrStreamUnsupported is returned by StreamEvents when the deployment has
+// no event stream, so callers can fall back to polling ListEvents.
+var ErrStreamUnsupported = errors.New("event stream not supported by this deployment")
+
+// StreamEvents calls onSnapshot with every event, first as they are now and
+// then each time one changes. It returns when ctx is done, the stream ends,
+// or onSnapshot returns an error. Retries do not apply; callers reconnect.
+func (c *Client) StreamEvents(ctx context.Context, onSnapshot func([]Event) error) error {
+	token := ""
+	if c.tokens != nil {
+		var err error
+		if token, err = c.tokens.Token(ctx); err != nil {
+			return err
+		}
+	}
+
+	connectCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(connectCtx, http.MethodGet, c.baseURL+"/api/events/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", c.userAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// The timeout covers connecting only, so the stream can stay open.
+	var timer *time.Timer
+	if c.timeout > 0 {
+		timer = time.AfterFunc(c.timeout, cancel)
+	}
+	resp, err := c.httpClient.Do(req)
+	if timer != nil && !timer.Stop() && ctx.Err() == nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return fmt.Errorf("request failed: no response from %s within %s", req.URL, c.timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	return readStream(resp, onSnapshot)
+}
+
+func readStream(resp *http.Response, onSnapshot func([]Event) error) error {
+	// Deployments without the stream route answer with a 404, or with the
+	// event-detail route's 400 for the ID "stream".
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusBadRequest:
+		return ErrStreamUnsupported
+	}
+	if resp.StatusCode >= 400 {
+		raw, _ := io.ReadAll(resp.Body)
+		return apiError(resp.StatusCode, raw)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		return ErrStreamUnsupported
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024) // a snapshot is one data line
+	var event string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := dispatchStreamEvent(event, data.String(), onSnapshot); err != nil {
+				return err
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment, used as a heartbeat
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("event stream: %w", err)
+	}
+	return fmt.Errorf("event stream: %w", io.ErrUnexpectedEOF)
+}
+
+func dispatchStreamEvent(event, data string, onSnapshot func([]Event) error) error {
+	switch event {
+	case "snapshot":
+		var snapshot struct {
+			Events []Event `json:"events"`
+		}
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return fmt.Errorf("invalid event stream snapshot: %w", err)
+		}
+		return onSnapshot(snapshot.Events)
+	case "error":
+		var body struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal([]byte(data), &body)
+		return &Error{Status: http.StatusBadGateway, Code: CodeServerError, Message: "event stream: " + body.Error}
+	}
+	return nil
+}
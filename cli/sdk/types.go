@@ -0,0 +1,207 @@
+// / cli/sdk/types.go — Typed models mirroring the Convex schema
+// / Decoded from API responses; field names follow convex/schema.ts.
+package sdk
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Millis is a Convex timestamp: milliseconds since the Unix epoch. Convex
+// stores numbers as float64, so fractional values are accepted and truncated.
+type Millis int64
+
+func (m *Millis) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*m = Millis(math.Trunc(f))
+	return nil
+}
+
+// Time converts m to a time.Time.
+func (m Millis) Time() time.Time {
+	return time.UnixMilli(int64(m))
+}
+
+// MillisOf converts t to a Convex timestamp.
+func MillisOf(t time.Time) Millis {
+	return Millis(t.UnixMilli())
+}
+
+func (m Millis) String() string {
+	return m.Time().Format(time.RFC3339)
+}
+
+// ===== Events =====
+
+type EventStatus string
+
+const (
+	EventDraft     EventStatus = "draft"
+	EventActive    EventStatus = "active"
+	EventEnded     EventStatus = "ended"
+	EventCancelled EventStatus = "cancelled"
+)
+
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+type SubmissionSource string
+
+const (
+	SubmittedByFoundationAdmin SubmissionSource = "foundation_admin"
+	SubmittedByProjectAdmin    SubmissionSource = "project_admin"
+	SubmittedByUser            SubmissionSource = "user_submission"
+)
+
+type Event struct {
+	ID               string           `json:"_id"`
+	CreationTime     Millis           `json:"_creationTime"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	StartTime        Millis           `json:"startTime"`
+	EndTime          Millis           `json:"endTime"`
+	Price            float64          `json:"price"` // USDC, human-readable (e.g. 10.5)
+	MaxTickets       int              `json:"maxTickets"`
+	TicketsSold      int              `json:"ticketsSold"`
+	TeamID           string           `json:"teamId,omitempty"`
+	ProjectID        string           `json:"projectId,omitempty"`
+	Sponsors         []string         `json:"sponsors"`
+	Location         string           `json:"location"`
+	OnChainEventID   *int64           `json:"onChainEventId,omitempty"`
+	ContractAddress  string           `json:"contractAddress,omitempty"`
+	CreatorAddress   string           `json:"creatorAddress"`
+	Status           EventStatus      `json:"status"`
+	SubmissionSource SubmissionSource `json:"submissionSource,omitempty"`
+	ModerationStatus ModerationStatus `json:"moderationStatus,omitempty"`
+	ModerationNotes  string           `json:"moderationNotes,omitempty"`
+	ReviewedByUserID string           `json:"reviewedByUserId,omitempty"`
+	ReviewedAt       *Millis          `json:"reviewedAt,omitempty"`
+	UpdatedAt        Millis           `json:"updatedAt,omitempty"` // last edit, cancellation or moderation decision; 0 if never
+	Sequence         int              `json:"sequence,omitempty"`  // number of such updates
+}
+
+// TicketsLeft is the remaining capacity, never negative.
+func (e Event) TicketsLeft() int {
+	if e.TicketsSold >= e.MaxTickets {
+		return 0
+	}
+	return e.MaxTickets - e.TicketsSold
+}
+
+// SoldOut reports whether every ticket has been sold.
+func (e Event) SoldOut() bool {
+	return e.TicketsLeft() == 0
+}
+
+// EventDetail is an event with its team, project and sponsors resolved.
+type EventDetail struct {
+	Event       Event     `json:"event"`
+	TeamName    string    `json:"teamName,omitempty"`
+	ProjectName string    `json:"projectName,omitempty"`
+	Sponsors    []Sponsor `json:"sponsors"`
+}
+
+// ===== Tickets =====
+
+type TicketStatus string
+
+const (
+	TicketActive      TicketStatus = "active"
+	TicketListed      TicketStatus = "listed"
+	TicketTransferred TicketStatus = "transferred"
+	TicketRefunded    TicketStatus = "refunded"
+)
+
+type Ticket struct {
+	ID            string       `json:"_id"`
+	CreationTime  Millis       `json:"_creationTime"`
+	EventID       string       `json:"eventId"`
+	TokenID       *int64       `json:"tokenId,omitempty"` // ERC-721 token ID on Monad
+	BuyerAddress  string       `json:"buyerAddress"`
+	BuyerAgentID  string       `json:"buyerAgentId,omitempty"`
+	PurchasePrice float64      `json:"purchasePrice"`
+	TxHash        string       `json:"txHash"`
+	QRCode        string       `json:"qrCode"`
+	CheckedInAt   *Millis      `json:"checkedInAt,omitempty"`
+	CheckedInBy   string       `json:"checkedInBy,omitempty"`
+	Status        TicketStatus `json:"status"`
+	ListedPrice   *float64     `json:"listedPrice,omitempty"`
+}
+
+// CheckedIn reports whether the ticket has been scanned at the door.
+func (t Ticket) CheckedIn() bool {
+	return t.CheckedInAt != nil
+}
+
+// TokenIDString renders the on-chain token ID, or "" when it is not minted.
+func (t Ticket) TokenIDString() string {
+	if t.TokenID == nil {
+		return ""
+	}
+	return strconv.FormatInt(*t.TokenID, 10)
+}
+
+// ===== Teams, projects, sponsors =====
+
+type Team struct {
+	ID            string   `json:"_id"`
+	CreationTime  Millis   `json:"_creationTime"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	WalletAddress string   `json:"walletAddress"`
+	Members       []string `json:"members"`
+}
+
+type ProjectStatus string
+
+const (
+	ProjectActive   ProjectStatus = "active"
+	ProjectArchived ProjectStatus = "archived"
+)
+
+type Project struct {
+	ID            string        `json:"_id"`
+	CreationTime  Millis        `json:"_creationTime"`
+	FoundationID  string        `json:"foundationId"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Status        ProjectStatus `json:"status"`
+	WalletAddress string        `json:"walletAddress,omitempty"`
+}
+
+type Sponsor struct {
+	ID            string   `json:"_id"`
+	CreationTime  Millis   `json:"_creationTime"`
+	Name          string   `json:"name"`
+	Logo          string   `json:"logo,omitempty"`
+	WalletAddress string   `json:"walletAddress"`
+	Contribution  *float64 `json:"contribution,omitempty"`
+}
+
+// ===== Agents =====
+
+type AgentStatus string
+
+const (
+	AgentActive    AgentStatus = "active"
+	AgentSuspended AgentStatus = "suspended"
+)
+
+type Agent struct {
+	ID            string      `json:"_id"`
+	CreationTime  Millis      `json:"_creationTime"`
+	Name          string      `json:"name"`
+	WalletAddress string      `json:"walletAddress"`
+	OwnerAddress  string      `json:"ownerAddress"`
+	Status        AgentStatus `json:"status"`
+}
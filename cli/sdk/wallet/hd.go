@@ -0,0 +1,164 @@
+// / cli/sdk/wallet/hd.go — BIP-39 mnemonics and BIP-32 account derivation
+// / Accounts follow the standard Ethereum path m/44'/60'/0'/0/<index>.
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicEntropyBits yields a 12-word phrase.
+const mnemonicEntropyBits = 128
+
+// seedFile is the on-disk form of an encrypted mnemonic. It reuses the
+// keystore's scrypt/AES crypto section so the same passphrase tooling applies.
+type seedFile struct {
+	Version int                 `json:"version"`
+	Kind    string              `json:"kind"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+}
+
+const seedFileKind = "bip39-mnemonic"
+
+// NewMnemonic generates a fresh 12-word BIP-39 phrase.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic collapses whitespace and checks the BIP-39 checksum.
+func NormalizeMnemonic(mnemonic string) (string, error) {
+	mnemonic = strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", fmt.Errorf("invalid BIP-39 mnemonic")
+	}
+	return mnemonic, nil
+}
+
+// DerivationPath returns m/44'/60'/0'/0/<index>.
+func DerivationPath(index uint32) accounts.DerivationPath {
+	path := make(accounts.DerivationPath, len(accounts.DefaultBaseDerivationPath))
+	copy(path, accounts.DefaultBaseDerivationPath)
+	path[len(path)-1] = index
+	return path
+}
+
+// DeriveKey derives the account key at index from mnemonic.
+func DeriveKey(mnemonic string, index uint32) (*ecdsa.PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid BIP-39 mnemonic: %w", err)
+	}
+
+	key, chainCode := masterKey(seed)
+	for _, child := range DerivationPath(index) {
+		key, chainCode, err = deriveChild(key, chainCode, child)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return crypto.ToECDSA(key)
+}
+
+// masterKey is the BIP-32 master key and chain code of seed.
+func masterKey(seed []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// deriveChild is BIP-32 private parent -> private child derivation.
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		data = append([]byte{0x00}, key...)
+	} else {
+		priv, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	return child.FillBytes(make([]byte, 32)), sum[32:], nil
+}
+
+// StoreMnemonic writes mnemonic to dir encrypted with passphrase and
+// returns the file path.
+func StoreMnemonic(dir, mnemonic, passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", fmt.Errorf("refusing to encrypt mnemonic with an empty passphrase")
+	}
+
+	cryptoJSON, err := keystore.EncryptDataV3([]byte(mnemonic), []byte(passphrase), keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt mnemonic: %w", err)
+	}
+	data, err := json.MarshalIndent(seedFile{Version: 3, Kind: seedFileKind, Crypto: cryptoJSON}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	path := filepath.Join(dir, "seed--"+ts+".json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// UnlockMnemonic decrypts the seed file at path.
+func UnlockMnemonic(path, passphrase string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read seed file: %w", err)
+	}
+	var file seedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("failed to parse seed file: %w", err)
+	}
+	if file.Kind != seedFileKind {
+		return "", fmt.Errorf("%s is not a BuddyEvents seed file", path)
+	}
+	plain, err := keystore.DecryptDataV3(file.Crypto, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("failed to unlock seed: %w", err)
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveKeyKnownAnswers(t *testing.T) {
+	tests := []struct {
+		index uint32
+		want  string
+	}{
+		{0, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{1, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+	}
+	for _, tt := range tests {
+		key, err := DeriveKey(testMnemonic, tt.index)
+		if err != nil {
+			t.Fatalf("DeriveKey(%d): %v", tt.index, err)
+		}
+		if got := crypto.PubkeyToAddress(key.PublicKey).Hex(); got != tt.want {
+			t.Errorf("DeriveKey(%d) = %s, want %s", tt.index, got, tt.want)
+		}
+	}
+}
+
+// TestDeriveChildVectors walks the private keys of the BIP-32 test vectors.
+// Vector 1 mixes hardened and normal indexes; vector 3 has a master key with
+// a leading zero byte, which must be kept when the 33-byte hardened input
+// 0x00 || key is built.
+func TestDeriveChildVectors(t *testing.T) {
+	const hardened = 0x80000000
+	tests := []struct {
+		name string
+		seed string
+		path []uint32
+		keys []string // private key after the master key and each index
+	}{
+		{
+			name: "vector 1",
+			seed: "000102030405060708090a0b0c0d0e0f",
+			path: []uint32{0 + hardened, 1, 2 + hardened},
+			keys: []string{
+				"e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+				"edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+				"3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+				"cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+			},
+		},
+		{
+			name: "vector 3",
+			seed: "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+			path: []uint32{0 + hardened},
+			keys: []string{
+				"00ddb80b067e0d4993197fe10f2657a844a384589847602d56f0c629c81aae32",
+				"491f7a2eebc7b57028e0d3faa0acda02e75c33b03c48fb288c41e2ea44e1daef",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, _ := hex.DecodeString(tt.seed)
+			key, chainCode := masterKey(seed)
+			if got := hex.EncodeToString(key); got != tt.keys[0] {
+				t.Fatalf("master key = %s, want %s", got, tt.keys[0])
+			}
+			for i, index := range tt.path {
+				var err error
+				key, chainCode, err = deriveChild(key, chainCode, index)
+				if err != nil {
+					t.Fatalf("deriveChild(%#x): %v", index, err)
+				}
+				if len(key) != 32 {
+					t.Fatalf("deriveChild(%#x) returned a %d-byte key", index, len(key))
+				}
+				if got := hex.EncodeToString(key); got != tt.keys[i+1] {
+					t.Fatalf("key after %#x = %s, want %s", index, got, tt.keys[i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	got, err := NormalizeMnemonic("  " + strings.ToUpper(strings.ReplaceAll(testMnemonic, " ", "\n ")) + "\n")
+	if err != nil || got != testMnemonic {
+		t.Errorf("NormalizeMnemonic = %q, %v; want %q", got, err, testMnemonic)
+	}
+	if _, err := NormalizeMnemonic(strings.Replace(testMnemonic, "about", "abandon", 1)); err == nil {
+		t.Error("NormalizeMnemonic accepted a phrase with a bad checksum")
+	}
+}
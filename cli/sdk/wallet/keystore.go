@@ -0,0 +1,71 @@
+// / cli/sdk/wallet/keystore.go — Encrypted key storage for agent wallets
+// / Web3 Secret Storage (scrypt) files, compatible with geth and Foundry.
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// Store writes key to dir as an encrypted keystore file and returns its path.
+func Store(dir string, key *ecdsa.PrivateKey, passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", fmt.Errorf("refusing to encrypt keystore with an empty passphrase")
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	data, err := keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    address,
+		PrivateKey: key,
+	}, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fileName(address))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Unlock decrypts the keystore file at path.
+func Unlock(path, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	return Decrypt(data, passphrase)
+}
+
+// Decrypt decrypts keystore JSON, e.g. one being imported.
+func Decrypt(data []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock keystore: %w", err)
+	}
+	return key.PrivateKey, nil
+}
+
+// fileName follows geth's UTC--<timestamp>--<address> convention.
+func fileName(address common.Address) string {
+	ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	return fmt.Sprintf("UTC--%s--%s", ts, hex.EncodeToString(address[:]))
+}
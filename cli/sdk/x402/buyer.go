@@ -0,0 +1,146 @@
+// / cli/sdk/x402/buyer.go — x402 payment-aware HTTP client
+// / Handles 402 challenge/response automatically for ticket purchases.
+package x402
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/OxFrancesco/BuddyEvents/cli/sdk"
+
+	x402core "github.com/coinbase/x402/go"
+	x402http "github.com/coinbase/x402/go/http"
+	evmexact "github.com/coinbase/x402/go/mechanisms/evm/exact/client"
+	evmsigners "github.com/coinbase/x402/go/signers/evm"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type BuyTicketResponse struct {
+	Success   bool   `json:"success"`
+	TicketID  string `json:"ticketId"`
+	QRCode    string `json:"qrCode"`
+	EventID   string `json:"eventId"`
+	Buyer     string `json:"buyer"`
+	Message   string `json:"message"`
+	Code      string `json:"code,omitempty"` // set on failures by current deployments
+	TxHash    string `json:"txHash"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Option configures BuyTicket.
+type Option func(*options)
+
+type options struct {
+	agentID    string
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// WithAgentID attributes the purchase to a registered agent.
+func WithAgentID(id string) Option {
+	return func(o *options) { o.agentID = id }
+}
+
+// WithHTTPClient sets the client the payment layer wraps (default
+// http.DefaultClient).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) { o.httpClient = hc }
+}
+
+// WithTimeout bounds the whole 402 challenge/response exchange when ctx has
+// no deadline of its own (default 45s; 0 disables it).
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// BuyTicket buys a ticket for the Convex event eventID from the deployment
+// at baseURL, paying the 402 challenge with a USDC authorization signed by
+// key. The buyer is the key's address.
+func BuyTicket(ctx context.Context, baseURL, eventID string, key *ecdsa.PrivateKey, opts ...Option) (*BuyTicketResponse, error) {
+	o := options{httpClient: http.DefaultClient, timeout: 45 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	agentID := o.agentID
+	buyerAddress := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	signer, err := evmsigners.NewClientSignerFromPrivateKey(hexutil.Encode(crypto.FromECDSA(key)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key for x402 signer: %w", err)
+	}
+
+	x402Client := x402core.Newx402Client().Register(
+		"eip155:*",
+		evmexact.NewExactEvmScheme(signer),
+	)
+
+	httpClient := x402http.WrapHTTPClientWithPayment(
+		o.httpClient,
+		x402http.Newx402HTTPClient(x402Client),
+	)
+
+	endpoint := strings.TrimRight(baseURL, "/")
+	endpoint += "/api/events/" + url.PathEscape(eventID) + "/buy"
+	query := url.Values{}
+	query.Set("buyer", buyerAddress)
+	if agentID != "" {
+		query.Set("agent", agentID)
+	}
+	endpoint += "?" + query.Encode()
+
+	if _, ok := ctx.Deadline(); !ok && o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-buyer-address", buyerAddress)
+	if agentID != "" {
+		req.Header.Set("x-agent-id", agentID)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("x402 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BuyTicketResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("ticket purchase failed: %w", sdk.NewError(resp.StatusCode, strings.TrimSpace(string(body))))
+		}
+		return nil, fmt.Errorf("invalid API response: %s", string(body))
+	}
+
+	if resp.StatusCode >= 400 || !result.Success {
+		status := resp.StatusCode
+		if status < 400 {
+			status = http.StatusBadRequest
+		}
+		apiErr := sdk.NewError(status, result.Message)
+		if result.Code != "" {
+			apiErr.Code = sdk.ErrorCode(result.Code)
+		}
+		return nil, fmt.Errorf("ticket purchase failed: %w", apiErr)
+	}
+
+	return &result, nil
+}
@@ -10,6 +10,7 @@
 
 import type * as agentRuns from "../agentRuns.js";
 import type * as agents from "../agents.js";
+import type * as crons from "../crons.js";
 import type * as events from "../events.js";
 import type * as lib_auth from "../lib/auth.js";
 import type * as projects from "../projects.js";
@@ -18,6 +19,7 @@ import type * as sponsors from "../sponsors.js";
 import type * as teams from "../teams.js";
 import type * as tickets from "../tickets.js";
 import type * as users from "../users.js";
+import type * as walletLogin from "../walletLogin.js";
 import type * as wallets from "../wallets.js";
 
 import type {
@@ -29,6 +31,7 @@ import type {
 declare const fullApi: ApiFromModules<{
   agentRuns: typeof agentRuns;
   agents: typeof agents;
+  crons: typeof crons;
   events: typeof events;
   "lib/auth": typeof lib_auth;
   projects: typeof projects;
@@ -37,6 +40,7 @@ declare const fullApi: ApiFromModules<{
   teams: typeof teams;
   tickets: typeof tickets;
   users: typeof users;
+  walletLogin: typeof walletLogin;
   wallets: typeof wallets;
 }>;
 
@@ -0,0 +1,14 @@
+/// convex/crons.ts — Scheduled maintenance jobs
+
+import { cronJobs } from "convex/server";
+import { internal } from "./_generated/api";
+
+const crons = cronJobs();
+
+crons.hourly(
+  "prune expired wallet login nonces",
+  { minuteUTC: 0 },
+  internal.walletLogin.pruneExpiredNonces,
+);
+
+export default crons;
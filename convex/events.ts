@@ -1,6 +1,6 @@
 /// convex/events.ts — Event CRUD + moderation flows
 
-import { internalMutation, mutation, query } from "./_generated/server";
+import { mutation, query } from "./_generated/server";
 import { v } from "convex/values";
 import type { Doc } from "./_generated/dataModel";
 import { requireAdmin, requireAdminOrService, requireSignedInUser } from "./lib/auth";
@@ -47,12 +47,20 @@ const eventValidator = v.object({
   moderationNotes: v.optional(v.string()),
   reviewedByUserId: v.optional(v.id("users")),
   reviewedAt: v.optional(v.number()),
+  updatedAt: v.optional(v.number()),
+  sequence: v.optional(v.number()),
 });
 
 function effectiveModerationStatus(event: Doc<"events">) {
   return event.moderationStatus ?? "approved";
 }
 
+// Fields to patch alongside any change calendar subscribers should see, so
+// feeds can send LAST-MODIFIED and an increasing SEQUENCE.
+function revision(event: Doc<"events">) {
+  return { updatedAt: Date.now(), sequence: (event.sequence ?? 0) + 1 };
+}
+
 // ========== Queries ==========
 
 export const list = query({
@@ -77,6 +85,119 @@ export const list = query({
   },
 });
 
+const eventSortValidator = v.union(
+  v.literal("created"),
+  v.literal("start"),
+  v.literal("price"),
+  v.literal("popularity"),
+);
+
+type EventSort = "created" | "start" | "price" | "popularity";
+
+function sortValue(event: Doc<"events">, sort: EventSort) {
+  switch (sort) {
+    case "start":
+      return event.startTime;
+    case "price":
+      return event.price;
+    case "popularity":
+      return event.ticketsSold;
+    default:
+      return event._creationTime;
+  }
+}
+
+// Filtered, sorted and paginated listing for the REST API. Cursors are
+// "<sort value>|<event id>" of the last event of the previous page, so pages
+// stay stable while events are added.
+export const search = query({
+  args: {
+    statuses: v.optional(v.array(eventStatusValidator)),
+    moderationStatus: v.optional(moderationStatusValidator),
+    teamId: v.optional(v.id("teams")),
+    projectId: v.optional(v.id("projects")),
+    from: v.optional(v.number()),
+    to: v.optional(v.number()),
+    maxPrice: v.optional(v.number()),
+    hasSeats: v.optional(v.boolean()),
+    location: v.optional(v.string()),
+    text: v.optional(v.string()),
+    sort: v.optional(eventSortValidator),
+    order: v.optional(v.union(v.literal("asc"), v.literal("desc"))),
+    limit: v.optional(v.number()),
+    cursor: v.optional(v.string()),
+  },
+  returns: v.object({
+    events: v.array(eventValidator),
+    nextCursor: v.union(v.string(), v.null()),
+  }),
+  handler: async (ctx, args) => {
+    let events = args.teamId
+      ? await ctx.db
+          .query("events")
+          .withIndex("by_team", (q) => q.eq("teamId", args.teamId))
+          .collect()
+      : args.projectId
+        ? await ctx.db
+            .query("events")
+            .withIndex("by_project", (q) => q.eq("projectId", args.projectId))
+            .collect()
+        : await ctx.db.query("events").collect();
+
+    const location = args.location?.trim().toLowerCase();
+    const terms = (args.text ?? "").toLowerCase().split(/\s+/).filter(Boolean);
+    events = events.filter((event) => {
+      if (args.statuses && args.statuses.length > 0 && !args.statuses.includes(event.status)) {
+        return false;
+      }
+      if (args.moderationStatus && effectiveModerationStatus(event) !== args.moderationStatus) {
+        return false;
+      }
+      if (args.teamId && event.teamId !== args.teamId) return false;
+      if (args.projectId && event.projectId !== args.projectId) return false;
+      if (args.from !== undefined && event.endTime <= args.from) return false;
+      if (args.to !== undefined && event.startTime >= args.to) return false;
+      if (args.maxPrice !== undefined && event.price > args.maxPrice) return false;
+      if (args.hasSeats && event.ticketsSold >= event.maxTickets) return false;
+      if (location && !event.location.toLowerCase().includes(location)) return false;
+      if (terms.length > 0) {
+        const haystack = `${event.name}\n${event.description}`.toLowerCase();
+        if (!terms.every((term) => haystack.includes(term))) return false;
+      }
+      return true;
+    });
+
+    const sort = args.sort ?? "created";
+    const defaultOrder = sort === "start" || sort === "price" ? "asc" : "desc";
+    const direction = (args.order ?? defaultOrder) === "asc" ? 1 : -1;
+    const compare = (value: number, id: string, other: Doc<"events">) => {
+      const otherValue = sortValue(other, sort);
+      if (value !== otherValue) return (value - otherValue) * direction;
+      return (id < other._id ? -1 : id > other._id ? 1 : 0) * direction;
+    };
+    events.sort((a, b) => compare(sortValue(a, sort), a._id, b));
+
+    if (args.cursor) {
+      const separator = args.cursor.lastIndexOf("|");
+      const value = Number(args.cursor.slice(0, separator));
+      const id = args.cursor.slice(separator + 1);
+      if (separator < 0 || Number.isNaN(value) || !id) throw new Error("Invalid cursor");
+      // Keep the events that sort after the cursor.
+      events = events.filter((event) => compare(value, id, event) < 0);
+    }
+
+    if (args.limit !== undefined && (!Number.isInteger(args.limit) || args.limit < 1)) {
+      throw new Error("Limit must be a positive integer");
+    }
+    if (args.limit === undefined || events.length <= args.limit) {
+      return { events, nextCursor: null };
+    }
+    const page = events.slice(0, args.limit);
+    const last = page[page.length - 1];
+    return { events: page, nextCursor: `${sortValue(last, sort)}|${last._id}` };
+  },
+});
+
 export const get = query({
   args: { id: v.id("events") },
   returns: v.union(eventValidator, v.null()),
@@ -97,6 +218,72 @@ export const getMany = query({
   },
 });
 
+export const getByOnChainEventId = query({
+  args: {
+    onChainEventId: v.number(),
+    contractAddress: v.optional(v.string()),
+  },
+  returns: v.union(eventValidator, v.null()),
+  handler: async (ctx, args) => {
+    const events = await ctx.db
+      .query("events")
+      .withIndex("by_on_chain_event_id", (q) =>
+        q.eq("onChainEventId", args.onChainEventId),
+      )
+      .collect();
+
+    const contractAddress = args.contractAddress?.toLowerCase();
+    return (
+      events.find(
+        (event) =>
+          !contractAddress ||
+          !event.contractAddress ||
+          event.contractAddress.toLowerCase() === contractAddress,
+      ) ?? null
+    );
+  },
+});
+
+const sponsorValidator = v.object({
+  _id: v.id("sponsors"),
+  _creationTime: v.number(),
+  name: v.string(),
+  logo: v.optional(v.string()),
+  walletAddress: v.string(),
+  contribution: v.optional(v.number()),
+});
+
+// Full detail for one event: team/project names and sponsor records resolved.
+export const getDetail = query({
+  args: { id: v.id("events") },
+  returns: v.union(
+    v.object({
+      event: eventValidator,
+      teamName: v.optional(v.string()),
+      projectName: v.optional(v.string()),
+      sponsors: v.array(sponsorValidator),
+    }),
+    v.null(),
+  ),
+  handler: async (ctx, args) => {
+    const event = await ctx.db.get(args.id);
+    if (!event) return null;
+
+    const [team, project, sponsors] = await Promise.all([
+      event.teamId ? ctx.db.get(event.teamId) : null,
+      event.projectId ? ctx.db.get(event.projectId) : null,
+      Promise.all(event.sponsors.map((id) => ctx.db.get(id))),
+    ]);
+
+    return {
+      event,
+      teamName: team?.name,
+      projectName: project?.name,
+      sponsors: sponsors.filter((sponsor): sponsor is Doc<"sponsors"> => sponsor !== null),
+    };
+  },
+});
+
 const sectionEventValidator = v.object({
   _id: v.id("events"),
   _creationTime: v.number(),
@@ -282,6 +469,7 @@ export const create = mutation({
     price: v.number(),
     maxTickets: v.number(),
     teamId: v.id("teams"),
+    projectId: v.optional(v.id("projects")),
     sponsors: v.optional(v.array(v.id("sponsors"))),
     location: v.string(),
     creatorAddress: v.string(),
@@ -293,6 +481,13 @@ export const create = mutation({
 
     const team = await ctx.db.get(args.teamId);
     if (!team) throw new Error("Team not found");
+    if (args.projectId) {
+      const project = await ctx.db.get(args.projectId);
+      if (!project) throw new Error("Project not found");
+      if (project.foundationId !== args.teamId) {
+        throw new Error("Project belongs to another team");
+      }
+    }
 
     return await ctx.db.insert("events", {
       name: args.name,
@@ -303,6 +498,7 @@ export const create = mutation({
       maxTickets: args.maxTickets,
       ticketsSold: 0,
       teamId: args.teamId,
+      projectId: args.projectId,
       sponsors: args.sponsors ?? [],
       location: args.location,
       creatorAddress: args.creatorAddress,
@@ -416,6 +612,7 @@ export const approveSubmission = mutation({
       moderationStatus: "approved",
       reviewedByUserId: admin._id,
       reviewedAt: Date.now(),
+      ...revision(event),
     };
     if (args.moderationNotes !== undefined) {
       patch.moderationNotes = args.moderationNotes;
@@ -445,6 +642,7 @@ export const rejectSubmission = mutation({
       status: "cancelled",
       reviewedByUserId: admin._id,
       reviewedAt: Date.now(),
+      ...revision(event),
     };
     if (args.moderationNotes !== undefined) {
       patch.moderationNotes = args.moderationNotes;
@@ -463,7 +661,10 @@ export const edit = mutation({
     startTime: v.optional(v.number()),
     endTime: v.optional(v.number()),
     price: v.optional(v.number()),
+    maxTickets: v.optional(v.number()),
     location: v.optional(v.string()),
+    teamId: v.optional(v.id("teams")),
+    projectId: v.optional(v.id("projects")),
     sponsors: v.optional(v.array(v.id("sponsors"))),
     serviceToken: v.optional(v.string()),
   },
@@ -474,14 +675,38 @@ export const edit = mutation({
     const event = await ctx.db.get(args.id);
     if (!event) throw new Error("Event not found");
 
+    if (args.teamId !== undefined && !(await ctx.db.get(args.teamId))) {
+      throw new Error("Team not found");
+    }
+    const projectId = args.projectId ?? event.projectId;
+    if (projectId && (args.projectId !== undefined || args.teamId !== undefined)) {
+      const project = await ctx.db.get(projectId);
+      if (!project) throw new Error("Project not found");
+      if (project.foundationId !== (args.teamId ?? event.teamId)) {
+        throw new Error("Project belongs to another team");
+      }
+    }
+
+    const startTime = args.startTime ?? event.startTime;
+    const endTime = args.endTime ?? event.endTime;
+    if (endTime <= startTime) throw new Error("End time must be after start time");
+    if (args.price !== undefined && args.price < 0) throw new Error("Price must not be negative");
+    if (args.maxTickets !== undefined && args.maxTickets < event.ticketsSold) {
+      throw new Error(`Max tickets cannot be below the ${event.ticketsSold} already sold`);
+    }
+
     const patch: Record<string, unknown> = {};
     if (args.name !== undefined) patch.name = args.name;
     if (args.description !== undefined) patch.description = args.description;
     if (args.startTime !== undefined) patch.startTime = args.startTime;
     if (args.endTime !== undefined) patch.endTime = args.endTime;
     if (args.price !== undefined) patch.price = args.price;
+    if (args.maxTickets !== undefined) patch.maxTickets = args.maxTickets;
     if (args.location !== undefined) patch.location = args.location;
+    if (args.teamId !== undefined) patch.teamId = args.teamId;
+    if (args.projectId !== undefined) patch.projectId = args.projectId;
     if (args.sponsors !== undefined) patch.sponsors = args.sponsors;
+    if (Object.keys(patch).length > 0) Object.assign(patch, revision(event));
 
     await ctx.db.patch(args.id, patch);
     return null;
@@ -500,20 +725,49 @@ export const cancel = mutation({
     const event = await ctx.db.get(args.id);
     if (!event) throw new Error("Event not found");
 
-    await ctx.db.patch(args.id, { status: "cancelled" as const });
+    await ctx.db.patch(args.id, { status: "cancelled" as const, ...revision(event) });
     return null;
   },
 });
 
-// Internal mutation for setting on-chain data after deployment
-export const setOnChainData = internalMutation({
+// Links an event to its on-chain counterpart after deployment
+export const setOnChainData = mutation({
   args: {
     id: v.id("events"),
     onChainEventId: v.number(),
     contractAddress: v.string(),
+    serviceToken: v.optional(v.string()),
   },
   returns: v.null(),
   handler: async (ctx, args) => {
+    await requireAdminOrService(ctx, args.serviceToken);
+
+    const event = await ctx.db.get(args.id);
+    if (!event) throw new Error("Event not found");
+    if (
+      event.onChainEventId !== undefined &&
+      (event.onChainEventId !== args.onChainEventId ||
+        event.contractAddress?.toLowerCase() !== args.contractAddress.toLowerCase())
+    ) {
+      throw new Error(
+        `Event already linked to on-chain event #${event.onChainEventId} on ${event.contractAddress}`,
+      );
+    }
+    const linked = await ctx.db
+      .query("events")
+      .withIndex("by_on_chain_event_id", (q) =>
+        q.eq("onChainEventId", args.onChainEventId),
+      )
+      .collect();
+    const other = linked.find(
+      (item) =>
+        item._id !== args.id &&
+        item.contractAddress?.toLowerCase() === args.contractAddress.toLowerCase(),
+    );
+    if (other) {
+      throw new Error(`On-chain event #${args.onChainEventId} is already linked to event ${other._id}`);
+    }
+
     await ctx.db.patch(args.id, {
       onChainEventId: args.onChainEventId,
       contractAddress: args.contractAddress,
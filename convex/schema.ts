@@ -43,13 +43,16 @@ export default defineSchema({
     moderationNotes: v.optional(v.string()),
     reviewedByUserId: v.optional(v.id("users")),
     reviewedAt: v.optional(v.number()),
+    updatedAt: v.optional(v.number()), // last edit, cancellation or moderation decision
+    sequence: v.optional(v.number()), // iCalendar SEQUENCE: how many times updatedAt was bumped
   })
     .index("by_status", ["status"])
     .index("by_team", ["teamId"])
     .index("by_creator", ["creatorAddress"])
     .index("by_moderation_status", ["moderationStatus"])
     .index("by_project", ["projectId"])
-    .index("by_team_and_moderation", ["teamId", "moderationStatus"]),
+    .index("by_team_and_moderation", ["teamId", "moderationStatus"])
+    .index("by_on_chain_event_id", ["onChainEventId"]),
 
   tickets: defineTable({
     eventId: v.id("events"),
@@ -68,11 +71,13 @@ export default defineSchema({
       v.literal("refunded"),
     ),
     listedPrice: v.optional(v.number()),
+    lastClaimAt: v.optional(v.number()), // issuedAt of the last accepted on-chain claim
   })
     .index("by_event", ["eventId"])
     .index("by_buyer", ["buyerAddress"])
     .index("by_status", ["status"])
-    .index("by_qr_code", ["qrCode"]),
+    .index("by_qr_code", ["qrCode"])
+    .index("by_tx_hash", ["txHash"]),
 
   teams: defineTable({
     name: v.string(),
@@ -181,4 +186,17 @@ export default defineSchema({
   })
     .index("by_event", ["eventId"])
     .index("by_ticket", ["ticketId"]),
+
+  walletLoginNonces: defineTable({
+    nonce: v.string(),
+    walletAddress: v.string(), // lowercase
+    clientIp: v.optional(v.string()), // for rate limiting
+    issuedAt: v.number(),
+    expiresAt: v.number(),
+    usedAt: v.optional(v.number()),
+  })
+    .index("by_nonce", ["nonce"])
+    .index("by_wallet", ["walletAddress", "issuedAt"])
+    .index("by_client_ip", ["clientIp", "issuedAt"])
+    .index("by_expires", ["expiresAt"]),
 });
@@ -10,7 +10,7 @@ import {
 } from "./_generated/server";
 import type { Doc, Id } from "./_generated/dataModel";
 import { v, type Infer } from "convex/values";
-import { requireSignedInUserOrService } from "./lib/auth";
+import { requireServiceAccess, requireSignedInUserOrService } from "./lib/auth";
 
 const ticketStatusValidator = v.union(
   v.literal("active"),
@@ -228,6 +228,75 @@ export const recordPurchaseAndIssueQr = mutation({
   },
 });
 
+// Records a direct BuyTicket contract purchase verified by the API route,
+// which has checked that buyerAddress signed the claim and owns the token.
+// Idempotent per txHash: a later claim re-issues the QR token, but only for
+// the wallet the ticket is recorded to, and each signed claim (claimIssuedAt)
+// is accepted once.
+export const recordOnChainPurchase = mutation({
+  args: {
+    eventId: v.id("events"),
+    tokenId: v.number(),
+    buyerAddress: v.string(),
+    buyerAgentId: v.optional(v.string()),
+    txHash: v.string(),
+    claimIssuedAt: v.number(),
+    serviceToken: v.string(),
+  },
+  returns: v.object({
+    ticketId: v.id("tickets"),
+    qrToken: v.string(),
+    qrTokenExpiresAt: v.number(),
+    alreadyRecorded: v.boolean(),
+  }),
+  handler: async (ctx, args) => {
+    requireServiceAccess(args.serviceToken);
+
+    const existing = await ctx.db
+      .query("tickets")
+      .withIndex("by_tx_hash", (q) => q.eq("txHash", args.txHash))
+      .first();
+    if (existing) {
+      if (existing.eventId !== args.eventId || existing.tokenId !== args.tokenId) {
+        throw new Error("Transaction already recorded for a different ticket");
+      }
+      if (existing.buyerAddress.toLowerCase() !== args.buyerAddress.toLowerCase()) {
+        throw new Error("Ticket is held by another wallet");
+      }
+      if (existing.lastClaimAt !== undefined && args.claimIssuedAt <= existing.lastClaimAt) {
+        throw new Error("Claim already used; sign a new one");
+      }
+      const qr = await issueTicketQrToken(ctx, {
+        ticketId: existing._id,
+        eventId: existing.eventId,
+        buyerAddress: existing.buyerAddress,
+      });
+      await ctx.db.patch(existing._id, { qrCode: qr.token, lastClaimAt: args.claimIssuedAt });
+      return {
+        ticketId: existing._id,
+        qrToken: qr.token,
+        qrTokenExpiresAt: qr.expiresAt,
+        alreadyRecorded: true,
+      };
+    }
+
+    const event = await ctx.db.get(args.eventId);
+    if (!event) throw new Error("Event not found");
+
+    const result = await recordPurchaseWithQrToken(ctx, {
+      eventId: args.eventId,
+      tokenId: args.tokenId,
+      buyerAddress: args.buyerAddress,
+      buyerAgentId: args.buyerAgentId,
+      purchasePrice: event.price,
+      txHash: args.txHash,
+      serviceToken: args.serviceToken,
+    });
+    await ctx.db.patch(result.ticketId, { lastClaimAt: args.claimIssuedAt });
+    return { ...result, alreadyRecorded: false };
+  },
+});
+
 async function recordPurchaseWithQrToken(
   ctx: MutationCtx,
   args: {
@@ -0,0 +1,112 @@
+/// convex/walletLogin.ts — Single-use nonces for Sign-In with Ethereum (EIP-4361)
+/// Issued and consumed only by the Next.js auth routes via the service token
+
+import { internal } from "./_generated/api";
+import { internalMutation, mutation } from "./_generated/server";
+import { v } from "convex/values";
+import { requireServiceAccess } from "./lib/auth";
+
+const NONCE_TTL_MS = 1000 * 60 * 5;
+
+// Nonces issued per wallet and per client IP within one TTL window. The
+// route is unauthenticated, so these bound how fast the table can grow.
+const MAX_NONCES_PER_WALLET = 5;
+const MAX_NONCES_PER_IP = 30;
+
+// Expired nonces removed per pruneExpiredNonces run.
+const PRUNE_BATCH = 500;
+
+const RATE_LIMITED_MESSAGE = "Too many sign-in requests; try again in a few minutes";
+
+export const issueNonce = mutation({
+  args: {
+    walletAddress: v.string(),
+    clientIp: v.optional(v.string()),
+    serviceToken: v.string(),
+  },
+  returns: v.object({
+    nonce: v.string(),
+    issuedAt: v.number(),
+    expiresAt: v.number(),
+  }),
+  handler: async (ctx, args) => {
+    requireServiceAccess(args.serviceToken);
+    const walletAddress = args.walletAddress.toLowerCase();
+    const issuedAt = Date.now();
+    const windowStart = issuedAt - NONCE_TTL_MS;
+
+    const forWallet = await ctx.db
+      .query("walletLoginNonces")
+      .withIndex("by_wallet", (q) =>
+        q.eq("walletAddress", walletAddress).gt("issuedAt", windowStart),
+      )
+      .take(MAX_NONCES_PER_WALLET);
+    if (forWallet.length >= MAX_NONCES_PER_WALLET) throw new Error(RATE_LIMITED_MESSAGE);
+    if (args.clientIp) {
+      const forIp = await ctx.db
+        .query("walletLoginNonces")
+        .withIndex("by_client_ip", (q) =>
+          q.eq("clientIp", args.clientIp).gt("issuedAt", windowStart),
+        )
+        .take(MAX_NONCES_PER_IP);
+      if (forIp.length >= MAX_NONCES_PER_IP) throw new Error(RATE_LIMITED_MESSAGE);
+    }
+
+    // EIP-4361 nonces are alphanumeric, at least 8 characters.
+    const nonce = crypto.randomUUID().replaceAll("-", "");
+    const expiresAt = issuedAt + NONCE_TTL_MS;
+    await ctx.db.insert("walletLoginNonces", {
+      nonce,
+      walletAddress,
+      clientIp: args.clientIp,
+      issuedAt,
+      expiresAt,
+    });
+    return { nonce, issuedAt, expiresAt };
+  },
+});
+
+export const consumeNonce = mutation({
+  args: {
+    nonce: v.string(),
+    walletAddress: v.string(),
+    serviceToken: v.string(),
+  },
+  returns: v.null(),
+  handler: async (ctx, args) => {
+    requireServiceAccess(args.serviceToken);
+    const record = await ctx.db
+      .query("walletLoginNonces")
+      .withIndex("by_nonce", (q) => q.eq("nonce", args.nonce))
+      .unique();
+    if (!record) throw new Error("Unknown nonce");
+    if (record.usedAt) throw new Error("Nonce already used");
+    if (record.expiresAt < Date.now()) throw new Error("Nonce expired");
+    if (record.walletAddress !== args.walletAddress.toLowerCase()) {
+      throw new Error("Nonce was issued to another wallet");
+    }
+    await ctx.db.patch(record._id, { usedAt: Date.now() });
+    return null;
+  },
+});
+
+// Deletes expired nonces, used or not; run hourly from crons.ts and again
+// right away while batches come back full. An expired nonce is refused
+// anyway, and so is the message signed with it.
+export const pruneExpiredNonces = internalMutation({
+  args: {},
+  returns: v.number(),
+  handler: async (ctx) => {
+    const expired = await ctx.db
+      .query("walletLoginNonces")
+      .withIndex("by_expires", (q) => q.lt("expiresAt", Date.now()))
+      .take(PRUNE_BATCH);
+    for (const record of expired) {
+      await ctx.db.delete(record._id);
+    }
+    if (expired.length === PRUNE_BATCH) {
+      await ctx.scheduler.runAfter(0, internal.walletLogin.pruneExpiredNonces, {});
+    }
+    return expired.length;
+  },
+});
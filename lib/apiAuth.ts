@@ -0,0 +1,22 @@
+/// lib/apiAuth.ts — Caller identity for API routes used by the web app and the CLI
+/// Accepts a Clerk session (cookie or `Authorization: Bearer <session JWT>`) or a Clerk API key.
+
+import { NextResponse } from "next/server";
+import { auth } from "@clerk/nextjs/server";
+
+export const CLI_LOGIN_HINT =
+  "Send `Authorization: Bearer <token>` with a Clerk session token or API key (CLI: buddyevents login)";
+
+/** Clerk user ID of the caller, or null when the request is unauthenticated. */
+export async function getCallerClerkId(): Promise<string | null> {
+  const result = await auth({ acceptsToken: ["session_token", "api_key"] });
+  return result.userId ?? null;
+}
+
+/** 401 response that tells API clients how to authenticate. */
+export function unauthorizedResponse() {
+  return NextResponse.json(
+    { error: "Unauthorized", code: "unauthorized", hint: CLI_LOGIN_HINT },
+    { status: 401 },
+  );
+}
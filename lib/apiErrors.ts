@@ -0,0 +1,94 @@
+/// lib/apiErrors.ts — JSON error bodies with stable machine codes for API routes
+/// Every error carries `{ error, code }`; clients branch on `code`, never on `error`.
+
+import { NextResponse } from "next/server";
+
+/** Stable error codes; the CLI SDK mirrors these in cli/sdk/errors.go. */
+export type ApiErrorCode =
+  | "unauthorized"
+  | "forbidden"
+  | "admin_required"
+  | "not_found"
+  | "sold_out"
+  | "event_inactive"
+  | "conflict"
+  | "invalid_request"
+  | "payment_required"
+  | "rate_limited"
+  | "server_error";
+
+/** Default code for a status when the route has nothing more specific. */
+export function codeForStatus(status: number): ApiErrorCode {
+  switch (status) {
+    case 401:
+      return "unauthorized";
+    case 402:
+      return "payment_required";
+    case 403:
+      return "forbidden";
+    case 404:
+      return "not_found";
+    case 409:
+      return "conflict";
+    case 429:
+      return "rate_limited";
+  }
+  return status >= 500 ? "server_error" : "invalid_request";
+}
+
+/** `{ error, code }` response; extra fields (hint, partial results) are merged in. */
+export function errorResponse(
+  status: number,
+  message: string,
+  code: ApiErrorCode = codeForStatus(status),
+  init: { extra?: Record<string, unknown>; headers?: HeadersInit } = {},
+) {
+  return NextResponse.json(
+    { ...init.extra, error: message, code },
+    { status, headers: init.headers },
+  );
+}
+
+// Messages thrown by Convex functions, and the status and code each maps to.
+// Convex wraps thrown messages in request details, so these are
+// case-insensitive substrings, checked in order.
+const convexErrors: { match: string; status: number; code: ApiErrorCode }[] = [
+  { match: "Authentication required", status: 401, code: "unauthorized" },
+  { match: "Admin access required", status: 403, code: "admin_required" },
+  { match: "Too many sign-in requests", status: 429, code: "rate_limited" },
+  { match: "Sold out", status: 409, code: "sold_out" },
+  { match: "Event not active", status: 409, code: "event_inactive" },
+  { match: "Ticket not available", status: 409, code: "event_inactive" },
+  { match: "held by another wallet", status: 403, code: "forbidden" },
+  { match: "does not match caller wallet", status: 403, code: "forbidden" },
+  { match: "must match", status: 403, code: "forbidden" },
+  { match: "Forbidden", status: 403, code: "forbidden" },
+  { match: "Claim already used", status: 401, code: "unauthorized" },
+  { match: "nonce", status: 401, code: "unauthorized" },
+  { match: "Max tickets cannot be below", status: 400, code: "invalid_request" },
+  { match: "not found", status: 404, code: "not_found" },
+  { match: "already", status: 409, code: "conflict" },
+];
+
+/** Status and code for an error thrown by a Convex call, or the fallbacks. */
+export function classifyConvexError(
+  message: string,
+  fallbackStatus: number,
+): { status: number; code: ApiErrorCode } {
+  const lower = message.toLowerCase();
+  const known = convexErrors.find((entry) => lower.includes(entry.match.toLowerCase()));
+  if (known) return { status: known.status, code: known.code };
+  return { status: fallbackStatus, code: codeForStatus(fallbackStatus) };
+}
+
+/** Error response for a caught exception, classified by its Convex message. */
+export function caughtErrorResponse(
+  error: unknown,
+  fallbackMessage: string,
+  fallbackStatus = 500,
+  extra?: Record<string, unknown>,
+) {
+  const message = error instanceof Error ? error.message : fallbackMessage;
+  const { status, code } = classifyConvexError(message, fallbackStatus);
+  return errorResponse(status, message, code, { extra });
+}
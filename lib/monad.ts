@@ -1,5 +1,6 @@
 /// lib/monad.ts — Monad chain configuration and contract constants
 
+import { defineChain, type Chain } from "viem";
 import { monadTestnet } from "viem/chains";
 
 // Re-export for convenience
@@ -16,6 +17,7 @@ export const MONAD_USDC_TESTNET =
 
 // Network identifiers
 export const MONAD_TESTNET_CHAIN_ID = 10143;
+export const MONAD_MAINNET_CHAIN_ID = 143;
 export const MONAD_CAIP2 = "eip155:10143" as const;
 export const MONAD_MAINNET_CAIP2 = "eip155:143" as const;
 
@@ -23,6 +25,27 @@ export const MONAD_MAINNET_CAIP2 = "eip155:143" as const;
 export const MONAD_TESTNET_RPC = "https://testnet-rpc.monad.xyz";
 export const MONAD_MAINNET_RPC = "https://rpc.monad.xyz";
 
+// The chain server-side reads go to: NEXT_PUBLIC_MONAD_CHAIN_ID (default
+// testnet) at NEXT_PUBLIC_MONAD_RPC (default that chain's public RPC).
+export function configuredMonadChain(): Chain {
+  const chainId = Number(process.env.NEXT_PUBLIC_MONAD_CHAIN_ID || MONAD_TESTNET_CHAIN_ID);
+  if (!Number.isInteger(chainId) || chainId <= 0) {
+    throw new Error("NEXT_PUBLIC_MONAD_CHAIN_ID must be a positive integer");
+  }
+  const rpc =
+    process.env.NEXT_PUBLIC_MONAD_RPC ||
+    (chainId === MONAD_MAINNET_CHAIN_ID ? MONAD_MAINNET_RPC : MONAD_TESTNET_RPC);
+  if (chainId === MONAD_TESTNET_CHAIN_ID) {
+    return { ...monadTestnet, rpcUrls: { default: { http: [rpc] } } };
+  }
+  return defineChain({
+    id: chainId,
+    name: chainId === MONAD_MAINNET_CHAIN_ID ? "Monad" : `Chain ${chainId}`,
+    nativeCurrency: monadTestnet.nativeCurrency,
+    rpcUrls: { default: { http: [rpc] } },
+  });
+}
+
 // BuddyEvents contract ABI (subset for frontend use)
 export const BUDDY_EVENTS_ABI = [
   {
@@ -124,6 +147,17 @@ export const BUDDY_EVENTS_ABI = [
     outputs: [{ name: "", type: "uint256" }],
     stateMutability: "view",
   },
+  {
+    type: "event",
+    name: "TicketPurchased",
+    inputs: [
+      { name: "eventId", type: "uint256", indexed: true },
+      { name: "tokenId", type: "uint256", indexed: true },
+      { name: "buyer", type: "address", indexed: true },
+      { name: "price", type: "uint256", indexed: false },
+    ],
+    anonymous: false,
+  },
 ] as const;
 
 // ERC20 ABI for USDC approve
@@ -156,3 +190,19 @@ export const ERC20_ABI = [
     stateMutability: "view",
   },
 ] as const;
+
+// Message a buyer signs (EIP-191) to claim the off-chain ticket for a direct
+// buyTicket transaction. issuedAt (RFC 3339, UTC) makes each claim single-use:
+// it must be recent and newer than the ticket's last claim. Must match the
+// CLI's chain.ClaimMessage.
+export function onChainPurchaseClaimMessage(
+  txHash: string,
+  tokenId: string | number | bigint,
+  issuedAt: string,
+) {
+  return `BuddyEvents on-chain purchase\nTx: ${txHash.toLowerCase()}\nToken: ${tokenId.toString()}\nIssued At: ${issuedAt}`;
+}
+
+// How old, or how far in the future, a claim's issuedAt may be.
+export const CLAIM_MAX_AGE_MS = 1000 * 60 * 5;
+export const CLAIM_MAX_SKEW_MS = 1000 * 60;
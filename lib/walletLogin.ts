@@ -0,0 +1,51 @@
+/// lib/walletLogin.ts — Sign-In with Ethereum (EIP-4361) for headless agents
+/// The agent signs a server-issued nonce; the server answers with an API token for the wallet's user.
+
+import { getAddress, verifyMessage, type Hex } from "viem";
+import { parseSiweMessage, validateSiweMessage } from "viem/siwe";
+
+export const WALLET_LOGIN_STATEMENT = "Sign in to BuddyEvents.";
+
+/** Lifetime of the API token issued for a wallet login. */
+export const WALLET_TOKEN_TTL_SECONDS = 60 * 60 * 24;
+
+export type VerifiedWalletLogin = {
+  address: `0x${string}`;
+  nonce: string;
+};
+
+/**
+ * Checks that `message` is an EIP-4361 message for `domain` and `chainId`
+ * that has not expired and that `signature` was produced by the address it
+ * names. Nonce freshness is checked separately against Convex.
+ */
+export async function verifyWalletLogin(
+  message: string,
+  signature: string,
+  domain: string,
+  chainId: number,
+): Promise<VerifiedWalletLogin> {
+  const parsed = parseSiweMessage(message);
+  if (!parsed.address || !parsed.nonce) {
+    throw new Error("Malformed sign-in message");
+  }
+  if (parsed.chainId !== chainId) {
+    throw new Error(
+      `Sign-in message is for chain ${parsed.chainId ?? "(none)"}; this server uses chain ${chainId}`,
+    );
+  }
+  if (!validateSiweMessage({ message: parsed, domain })) {
+    throw new Error("Sign-in message is for another domain or has expired");
+  }
+
+  const address = getAddress(parsed.address);
+  const valid = await verifyMessage({
+    address,
+    message,
+    signature: signature as Hex,
+  });
+  if (!valid) {
+    throw new Error("Signature does not match the sign-in message address");
+  }
+  return { address, nonce: parsed.nonce };
+}